@@ -0,0 +1,167 @@
+package pgstats
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ActivityHistory keeps recent samples of `pg_stat_activity` view in a bounded in-memory ring buffer.
+// Samples are collected by a background poller and retained for the retention period,
+// but no more than the capacity of the buffer.
+type ActivityHistory struct {
+	stats     *Stats
+	interval  time.Duration
+	retention time.Duration
+	poller    poller
+
+	mu   sync.Mutex
+	buf  []ActivitySample
+	head int
+	size int
+}
+
+// ActivitySample represents a row of pg_stat_activity view observed at a sample time.
+type ActivitySample struct {
+	SampleTime time.Time `json:"sample_time"` // Time when the row was observed
+	ActivityRow
+}
+
+// ActivityFilter selects samples of ActivityHistory. Zero value fields match any sample.
+type ActivityFilter struct {
+	From          time.Time // Samples observed at or after this time
+	To            time.Time // Samples observed at or before this time
+	Pid           int64     // Process ID of a backend
+	Usename       string    // Name of the user logged into a backend
+	Datname       string    // Name of the database a backend is connected to
+	WaitEventType string    // The type of event for which a backend is waiting
+	WaitEvent     string    // Wait event name
+}
+
+// NewActivityHistory creates a new ActivityHistory polling pg_stat_activity every interval.
+// At most capacity rows are retained, rows older than retention are discarded.
+// A non-positive interval is replaced with one second, a non-positive retention keeps rows until
+// they are pushed out by newer ones and a negative capacity is treated as zero, so nothing is retained.
+func (s *Stats) NewActivityHistory(interval, retention time.Duration, capacity int) *ActivityHistory {
+	if retention < 0 {
+		retention = 0
+	}
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &ActivityHistory{
+		stats:     s,
+		interval:  pollInterval(interval),
+		retention: retention,
+		buf:       make([]ActivitySample, capacity),
+	}
+}
+
+// Start starts polling in a background goroutine until ctx is done or Stop is called.
+// Calling Start on a running history does nothing.
+func (h *ActivityHistory) Start(ctx context.Context) {
+	h.poller.start(ctx, h.interval, h.sample)
+}
+
+// Stop stops polling and waits for the background goroutine to exit.
+// It returns the last error of polling, if any.
+func (h *ActivityHistory) Stop() error {
+	return h.poller.stop()
+}
+
+// Err returns the last error of polling, if any.
+func (h *ActivityHistory) Err() error {
+	return h.poller.lastErr()
+}
+
+// Add adds rows observed at the given time to the history.
+func (h *ActivityHistory) Add(rows []ActivityRow, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.buf) == 0 {
+		return
+	}
+	for _, row := range rows {
+		h.buf[(h.head+h.size)%len(h.buf)] = ActivitySample{SampleTime: at, ActivityRow: row}
+		if h.size < len(h.buf) {
+			h.size++
+		} else {
+			h.head = (h.head + 1) % len(h.buf)
+		}
+	}
+	h.expire(at)
+}
+
+// Query returns samples matching the filter, the oldest first.
+func (h *ActivityHistory) Query(filter ActivityFilter) []ActivitySample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.expire(time.Now())
+
+	data := []ActivitySample{}
+	for i := 0; i < h.size; i++ {
+		sample := h.buf[(h.head+i)%len(h.buf)]
+		if filter.match(sample) {
+			data = append(data, sample)
+		}
+	}
+	return data
+}
+
+// Dump writes samples matching the filter to w as JSON lines, the oldest first.
+func (h *ActivityHistory) Dump(w io.Writer, filter ActivityFilter) error {
+	enc := json.NewEncoder(w)
+	for _, sample := range h.Query(filter) {
+		if err := enc.Encode(sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *ActivityHistory) sample(ctx context.Context) error {
+	rows, err := h.stats.fetchActivity(ctx)
+	if err != nil {
+		return err
+	}
+	h.Add(rows, time.Now())
+	return nil
+}
+
+// expire drops samples older than retention, must be called with h.mu held.
+func (h *ActivityHistory) expire(now time.Time) {
+	if h.retention <= 0 {
+		return
+	}
+	deadline := now.Add(-h.retention)
+	for h.size > 0 && h.buf[h.head].SampleTime.Before(deadline) {
+		h.buf[h.head] = ActivitySample{}
+		h.head = (h.head + 1) % len(h.buf)
+		h.size--
+	}
+}
+
+func (f ActivityFilter) match(s ActivitySample) bool {
+	switch {
+	case !f.From.IsZero() && s.SampleTime.Before(f.From):
+		return false
+	case !f.To.IsZero() && s.SampleTime.After(f.To):
+		return false
+	case f.Pid != 0 && s.Pid != f.Pid:
+		return false
+	case f.Usename != "" && (s.Usename == nil || s.Usename.String != f.Usename):
+		return false
+	case f.Datname != "" && (s.Datname == nil || s.Datname.String != f.Datname):
+		return false
+	case f.WaitEventType != "" && (s.WaitEventType == nil || s.WaitEventType.String != f.WaitEventType):
+		return false
+	case f.WaitEvent != "" && (s.WaitEvent == nil || s.WaitEvent.String != f.WaitEvent):
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,86 @@
+package pgstats
+
+import (
+	"database/sql"
+	"sort"
+)
+
+// ParallelQuery represents a parallel group leader with its parallel query workers.
+type ParallelQuery struct {
+	Leader  *ActivityRow  `json:"leader"`  // Row of the parallel group leader, nil if the leader is not in the Activity rows
+	Workers []ActivityRow `json:"workers"` // Rows of parallel query workers of the leader
+}
+
+// ParallelQueries groups parallel query workers from Activity rows under their leader.
+// Only leaders with at least one worker are returned, ordered by leader pid.
+// Requires PostgreSQL 13+, on older versions no groups are returned.
+func ParallelQueries(rows []ActivityRow) []ParallelQuery {
+	leaders := make(map[int64]*ActivityRow, len(rows))
+	for i := range rows {
+		leaders[rows[i].Pid] = &rows[i]
+	}
+
+	groups := map[int64]*ParallelQuery{}
+	pids := []int64{}
+	for _, row := range rows {
+		// Before PostgreSQL 14 leader_pid of a leader is its own pid.
+		if isNull(row.LeaderPid) || row.LeaderPid.Int64 == row.Pid {
+			continue
+		}
+
+		pid := row.LeaderPid.Int64
+		group, ok := groups[pid]
+		if !ok {
+			group = &ParallelQuery{Leader: leaders[pid]}
+			groups[pid] = group
+			pids = append(pids, pid)
+		}
+		group.Workers = append(group.Workers, row)
+	}
+
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+	data := make([]ParallelQuery, 0, len(pids))
+	for _, pid := range pids {
+		data = append(data, *groups[pid])
+	}
+	return data
+}
+
+// ActivityStatement represents an Activity row with statistics of its most recent query.
+type ActivityStatement struct {
+	ActivityRow
+	Statement *StatementsRow `json:"statement"` // Row of pg_stat_statements with the same query_id, database and user, nil if not found
+}
+
+// ActivityStatements joins Activity rows with Statements rows by query_id, database and user.
+// Requires PostgreSQL 14+ with compute_query_id enabled, otherwise Statement is always nil.
+// If the statement is tracked both as a top-level and a nested one, the top-level row is used.
+func ActivityStatements(activity []ActivityRow, statements []StatementsRow) []ActivityStatement {
+	type statementKey struct {
+		queryid, dbid, userid int64
+	}
+
+	byKey := make(map[statementKey]*StatementsRow, len(statements))
+	for i := range statements {
+		st := &statements[i]
+		key := statementKey{st.Queryid, st.Dbid, st.Userid}
+		if prev, ok := byKey[key]; !ok || (!isTrue(prev.Toplevel) && isTrue(st.Toplevel)) {
+			byKey[key] = st
+		}
+	}
+
+	data := make([]ActivityStatement, 0, len(activity))
+	for _, row := range activity {
+		item := ActivityStatement{ActivityRow: row}
+		if !isNull(row.QueryID) && !isNull(row.Datid) && !isNull(row.Usesysid) {
+			item.Statement = byKey[statementKey{row.QueryID.Int64, row.Datid.Int64, row.Usesysid.Int64}]
+		}
+		data = append(data, item)
+	}
+	return data
+}
+
+func isTrue(v *sql.NullBool) bool {
+	return v != nil && v.Valid && v.Bool
+}
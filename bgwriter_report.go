@@ -0,0 +1,127 @@
+package pgstats
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BgWriterSettings represents settings which affect checkpoints and the background writer.
+type BgWriterSettings struct {
+	MaxWalSize          string        `json:"max_wal_size"`          // Value of max_wal_size setting, e.g. 1GB
+	CheckpointTimeout   time.Duration `json:"checkpoint_timeout"`    // Value of checkpoint_timeout setting
+	BgwriterLruMaxpages int64         `json:"bgwriter_lru_maxpages"` // Value of bgwriter_lru_maxpages setting
+}
+
+// BgWriterReport represents checkpoint and background writer health between two BgWriter readings.
+type BgWriterReport struct {
+	CheckpointsTimed       int64    `json:"checkpoints_timed"`         // Number of scheduled checkpoints
+	CheckpointsReq         int64    `json:"checkpoints_req"`           // Number of requested checkpoints
+	RequestedRatio         float64  `json:"requested_ratio"`           // Fraction of requested checkpoints among all checkpoints
+	BuffersCheckpoint      int64    `json:"buffers_checkpoint"`        // Number of buffers written by the checkpointer
+	BuffersClean           int64    `json:"buffers_clean"`             // Number of buffers written by the background writer
+	BuffersBackend         int64    `json:"buffers_backend"`           // Number of buffers written directly by backends
+	CheckpointerRatio      float64  `json:"checkpointer_ratio"`        // Fraction of buffers written by the checkpointer
+	BgWriterRatio          float64  `json:"bgwriter_ratio"`            // Fraction of buffers written by the background writer
+	BackendRatio           float64  `json:"backend_ratio"`             // Fraction of buffers written directly by backends
+	MaxWrittenClean        int64    `json:"maxwritten_clean"`          // Number of times the background writer stopped because of bgwriter_lru_maxpages
+	BuffersBackendFsync    int64    `json:"buffers_backend_fsync"`     // Number of times a backend had to execute its own fsync call
+	AvgCheckpointWriteTime float64  `json:"avg_checkpoint_write_time"` // Average time spent writing files per checkpoint, in milliseconds
+	AvgCheckpointSyncTime  float64  `json:"avg_checkpoint_sync_time"`  // Average time spent synchronizing files per checkpoint, in milliseconds
+	StatsReset             bool     `json:"stats_reset"`               // True if statistics were reset between the readings or prev is empty, the report is cumulative then
+	Warnings               []string `json:"warnings"`                  // Human readable recommendations
+}
+
+// Thresholds used by AnalyzeBgWriter to produce warnings.
+const (
+	bgWriterMaxRequestedRatio = 0.1
+	bgWriterMaxBackendRatio   = 0.1
+	bgWriterMaxWriteTimeRatio = 0.9
+)
+
+// BgWriterSettings returns settings needed for AnalyzeBgWriter.
+func (s *Stats) BgWriterSettings(ctx context.Context) (BgWriterSettings, error) {
+	const query = `SELECT
+	current_setting('max_wal_size'),
+	(SELECT setting FROM pg_settings WHERE name = 'checkpoint_timeout')::bigint,
+	(SELECT setting FROM pg_settings WHERE name = 'bgwriter_lru_maxpages')::bigint`
+
+	var res BgWriterSettings
+	var timeout int64
+
+	err := s.db.QueryRowContext(ctx, query).Scan(
+		&res.MaxWalSize,
+		&timeout,
+		&res.BgwriterLruMaxpages,
+	)
+	res.CheckpointTimeout = time.Duration(timeout) * time.Second
+	return res, err
+}
+
+// AnalyzeBgWriter compares two BgWriter readings, prev taken before curr, and reports checkpoint and background writer health.
+// If statistics were reset between the readings, curr is analyzed alone.
+func AnalyzeBgWriter(prev, curr BgWriterView, settings BgWriterSettings) BgWriterReport {
+	if timeChanged(prev.StatsReset, curr.StatsReset) {
+		prev = BgWriterView{}
+	}
+
+	r := BgWriterReport{
+		CheckpointsTimed:    counterDelta(prev.CheckpointsTimed, curr.CheckpointsTimed),
+		CheckpointsReq:      counterDelta(prev.CheckpointsReq, curr.CheckpointsReq),
+		BuffersCheckpoint:   counterDelta(prev.BuffersCheckpoint, curr.BuffersCheckpoint),
+		BuffersClean:        counterDelta(prev.BuffersClean, curr.BuffersClean),
+		BuffersBackend:      counterDelta(prev.BuffersBackend, curr.BuffersBackend),
+		MaxWrittenClean:     counterDelta(prev.MaxWrittenClean, curr.MaxWrittenClean),
+		BuffersBackendFsync: counterDelta(prev.BuffersBackendFsync, curr.BuffersBackendFsync),
+		StatsReset:          prev.CheckpointsTimed == nil,
+		Warnings:            []string{},
+	}
+
+	checkpoints := r.CheckpointsTimed + r.CheckpointsReq
+	if checkpoints > 0 {
+		r.RequestedRatio = float64(r.CheckpointsReq) / float64(checkpoints)
+	}
+	// Since PostgreSQL 18 timed and requested checkpoints include skipped ones which take no time.
+	if !isNull(curr.CheckpointsDone) {
+		checkpoints = counterDelta(prev.CheckpointsDone, curr.CheckpointsDone)
+	}
+	if checkpoints > 0 {
+		r.AvgCheckpointWriteTime = floatDelta(prev.CheckpointWriteTime, curr.CheckpointWriteTime) / float64(checkpoints)
+		r.AvgCheckpointSyncTime = floatDelta(prev.CheckpointSyncTime, curr.CheckpointSyncTime) / float64(checkpoints)
+	}
+
+	written := r.BuffersCheckpoint + r.BuffersClean + r.BuffersBackend
+	if written > 0 {
+		r.CheckpointerRatio = float64(r.BuffersCheckpoint) / float64(written)
+		r.BgWriterRatio = float64(r.BuffersClean) / float64(written)
+		r.BackendRatio = float64(r.BuffersBackend) / float64(written)
+	}
+
+	if r.RequestedRatio > bgWriterMaxRequestedRatio {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(
+			"%.0f%% of checkpoints are requested rather than timed, consider increasing max_wal_size (currently %s)",
+			100*r.RequestedRatio, settings.MaxWalSize))
+	}
+	timeout := float64(settings.CheckpointTimeout / time.Millisecond)
+	if timeout > 0 && r.AvgCheckpointWriteTime > bgWriterMaxWriteTimeRatio*timeout {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(
+			"average checkpoint write time %.0fs is close to checkpoint_timeout (%s), checkpoints do not keep up with writes",
+			r.AvgCheckpointWriteTime/1000, settings.CheckpointTimeout))
+	}
+	if r.BackendRatio > bgWriterMaxBackendRatio {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(
+			"backends wrote %.0f%% of buffers themselves, consider increasing shared_buffers or making the background writer more aggressive",
+			100*r.BackendRatio))
+	}
+	if r.MaxWrittenClean > 0 {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(
+			"background writer stopped %d times after writing bgwriter_lru_maxpages (currently %d) buffers, consider increasing it",
+			r.MaxWrittenClean, settings.BgwriterLruMaxpages))
+	}
+	if r.BuffersBackendFsync > 0 {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(
+			"backends executed their own fsync %d times because the checkpointer fsync request queue was full",
+			r.BuffersBackendFsync))
+	}
+	return r
+}
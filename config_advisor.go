@@ -0,0 +1,173 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ConfigAdvisorInput represents data analyzed by AdviseConfig. Only Settings are required.
+type ConfigAdvisorInput struct {
+	Settings          []SettingsRow   // Rows of pg_settings view
+	Databases         []DatabaseRow   // Rows of pg_stat_database view
+	BgWriter          *BgWriterView   // Content of pg_stat_bgwriter view
+	Statements        []StatementsRow // Rows of pg_stat_statements view
+	Activity          []ActivityRow   // Rows of pg_stat_activity view
+	StatementsDealloc *sql.NullInt64  // Number of pg_stat_statements entries deallocated, from pg_stat_statements_info view
+}
+
+// ConfigAdvice represents a setting which looks misconfigured.
+type ConfigAdvice struct {
+	Setting string `json:"setting"` // Name of the setting
+	Current string `json:"current"` // Current value of the setting, with unit if any
+	Message string `json:"message"` // Human readable reason and recommendation
+}
+
+// Thresholds used by AdviseConfig.
+const (
+	configMinHitRatio          = 0.99
+	configMaxBackendRatio      = 0.1
+	configMaxTempBytesPerQuery = 1 << 20
+)
+
+// ConfigAdvisor collects settings and statistics and returns advices produced by AdviseConfig.
+// pg_stat_statements data is used only if the extension is installed in the current database and loaded.
+func (s *Stats) ConfigAdvisor(ctx context.Context) ([]ConfigAdvice, error) {
+	var in ConfigAdvisorInput
+	var err error
+
+	if in.Settings, err = s.fetchSettings(ctx); err != nil {
+		return nil, err
+	}
+	if in.Databases, err = s.fetchDatabases(); err != nil {
+		return nil, err
+	}
+	bgWriter, err := s.fetchBgWriter()
+	if err != nil {
+		return nil, err
+	}
+	in.BgWriter = &bgWriter
+	if in.Activity, err = s.fetchActivity(ctx); err != nil {
+		return nil, err
+	}
+
+	ext, err := s.getExtensionVersionContext(ctx, "pg_stat_statements")
+	if err != nil {
+		return nil, err
+	}
+	// The extension can be installed but not loaded via shared_preload_libraries,
+	// its views fail then and the advisor works without them.
+	if ext > 0 {
+		if statements, err := s.fetchStatements(); err == nil {
+			in.Statements = statements
+		}
+	}
+	if ext >= 109 && in.Statements != nil {
+		const deallocQuery = `SELECT dealloc FROM pg_stat_statements_info`
+
+		dealloc := &sql.NullInt64{}
+		if err := s.db.QueryRowContext(ctx, deallocQuery).Scan(dealloc); err == nil {
+			in.StatementsDealloc = dealloc
+		}
+	}
+	return AdviseConfig(in), nil
+}
+
+// AdviseConfig applies rules to settings and statistics and returns settings which look misconfigured:
+// shared_buffers too small for the observed cache hit ratio, work_mem too small given temporary bytes written per query,
+// track_io_timing disabled, track_activity_query_size truncating queries
+// and pg_stat_statements.max too small given deallocations.
+func AdviseConfig(in ConfigAdvisorInput) []ConfigAdvice {
+	settings := make(map[string]SettingsRow, len(in.Settings))
+	for _, row := range in.Settings {
+		settings[row.Name] = row
+	}
+
+	advices := []ConfigAdvice{}
+	advise := func(name, format string, args ...interface{}) {
+		setting, ok := settings[name]
+		if !ok {
+			return
+		}
+		current := setting.Setting
+		if setting.Unit != nil && setting.Unit.Valid {
+			current += " (" + setting.Unit.String + ")"
+		}
+		advices = append(advices, ConfigAdvice{
+			Setting: name,
+			Current: current,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var hit, read, tempFiles, tempBytes, xacts int64
+	var readTime float64
+	for _, db := range in.Databases {
+		hit += counterDelta(nil, db.BlksHit)
+		read += counterDelta(nil, db.BlksRead)
+		tempFiles += counterDelta(nil, db.TempFiles)
+		tempBytes += counterDelta(nil, db.TempBytes)
+		xacts += counterDelta(nil, db.XactCommit) + counterDelta(nil, db.XactRollback)
+		readTime += floatDelta(nil, db.BlkReadTime)
+	}
+
+	// statements are counted by pg_stat_statements if installed, by transactions otherwise
+	var queries int64
+	for _, st := range in.Statements {
+		queries += st.Calls
+	}
+	if queries == 0 {
+		queries = xacts
+	}
+
+	if ratio := newHitRatio(0, "", "", "database", hit, read, configMinHitRatio); ratio.Low {
+		advise("shared_buffers", "cache hit ratio is %.2f%%, consider increasing shared_buffers", 100*ratio.Ratio.Float64)
+	} else if in.BgWriter != nil {
+		report := AnalyzeBgWriter(BgWriterView{}, *in.BgWriter, BgWriterSettings{})
+		if report.BackendRatio > configMaxBackendRatio {
+			advise("shared_buffers", "backends wrote %.0f%% of buffers themselves, consider increasing shared_buffers", 100*report.BackendRatio)
+		}
+	}
+
+	if queries > 0 && tempBytes/queries > configMaxTempBytesPerQuery {
+		var worst StatementsRow
+		for _, st := range in.Statements {
+			if st.TempBlksWritten > worst.TempBlksWritten {
+				worst = st
+			}
+		}
+		if worst.TempBlksWritten > 0 {
+			advise("work_mem", "queries created %d temporary files of %d bytes in total (%d bytes per query), the top statement (queryid %d) wrote %d temporary blocks, consider increasing work_mem",
+				tempFiles, tempBytes, tempBytes/queries, worst.Queryid, worst.TempBlksWritten)
+		} else {
+			advise("work_mem", "queries created %d temporary files of %d bytes in total (%d bytes per query), consider increasing work_mem",
+				tempFiles, tempBytes, tempBytes/queries)
+		}
+	}
+
+	if setting, ok := settings["track_io_timing"]; ok && setting.Bool != nil && !setting.Bool.Bool {
+		if readTime == 0 && read > 0 {
+			advise("track_io_timing", "track_io_timing is off, so blk_read_time and blk_write_time are always zero, consider enabling it")
+		} else {
+			advise("track_io_timing", "track_io_timing is off, consider enabling it to collect IO timings")
+		}
+	}
+
+	if setting, ok := settings["track_activity_query_size"]; ok && setting.Int != nil && setting.Int.Valid {
+		truncated := 0
+		for _, row := range in.Activity {
+			// the query text is limited to track_activity_query_size bytes including the terminating zero
+			if row.Query != nil && int64(len(row.Query.String)) >= setting.Int.Int64-1 {
+				truncated++
+			}
+		}
+		if truncated > 0 {
+			advise("track_activity_query_size", "%d queries in pg_stat_activity are truncated, consider increasing track_activity_query_size", truncated)
+		}
+	}
+
+	if in.StatementsDealloc != nil && in.StatementsDealloc.Valid && in.StatementsDealloc.Int64 > 0 {
+		advise("pg_stat_statements.max", "%d least executed statements were deallocated, consider increasing pg_stat_statements.max", in.StatementsDealloc.Int64)
+	}
+	return advices
+}
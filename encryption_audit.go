@@ -0,0 +1,141 @@
+package pgstats
+
+import (
+	"context"
+	"sort"
+)
+
+// UnencryptedConnections represents client connections of a user from an address that use neither SSL nor GSSAPI encryption.
+type UnencryptedConnections struct {
+	Usename     string  `json:"usename"`     // Name of the user, empty if unknown
+	ClientAddr  string  `json:"client_addr"` // IP address of the client
+	Connections int64   `json:"connections"` // Number of unencrypted connections
+	Pids        []int64 `json:"pids"`        // Process IDs of the backends serving these connections
+}
+
+// EncryptionAudit lists unencrypted client connections grouped by user and address.
+// pg_stat_activity, pg_stat_ssl and pg_stat_gssapi are read by one query, so a pid reused between reads cannot be mismatched.
+// GSSAPI encryption is taken into account since PostgreSQL 12.
+func (s *Stats) EncryptionAudit(ctx context.Context) ([]UnencryptedConnections, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := encryptionAuditQuery12
+	if version < 12 {
+		query = encryptionAuditQuery95
+	}
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activity []ActivityRow
+	var ssl []SslRow
+	var gssapi []GssapiRow
+	for rows.Next() {
+		var row ActivityRow
+		var sslRow SslRow
+		var gssapiRow GssapiRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Usename,
+			&row.ClientAddr,
+			&sslRow.Ssl,
+			&gssapiRow.Encrypted,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sslRow.Pid, gssapiRow.Pid = row.Pid, row.Pid
+		activity = append(activity, row)
+		ssl = append(ssl, sslRow)
+		gssapi = append(gssapi, gssapiRow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return AuditEncryption(activity, ssl, gssapi), nil
+}
+
+const encryptionAuditQuery12 = `SELECT
+	a.pid,
+	a.usename,
+	a.client_addr,
+	coalesce(s.ssl, false),
+	coalesce(g.encrypted, false)
+	FROM pg_stat_activity a
+	LEFT JOIN pg_stat_ssl s ON s.pid = a.pid
+	LEFT JOIN pg_stat_gssapi g ON g.pid = a.pid
+	WHERE a.client_addr IS NOT NULL`
+
+const encryptionAuditQuery95 = `SELECT
+	a.pid,
+	a.usename,
+	a.client_addr,
+	coalesce(s.ssl, false),
+	false
+	FROM pg_stat_activity a
+	LEFT JOIN pg_stat_ssl s ON s.pid = a.pid
+	WHERE a.client_addr IS NOT NULL`
+
+// AuditEncryption joins Activity rows with Ssl and Gssapi rows by pid and returns unencrypted client connections
+// grouped by user and address, ordered by number of connections.
+// Connections over Unix sockets are not reported, gssapi may be nil before PostgreSQL 12.
+func AuditEncryption(activity []ActivityRow, ssl []SslRow, gssapi []GssapiRow) []UnencryptedConnections {
+	encrypted := make(map[int64]bool, len(ssl)+len(gssapi))
+	for _, row := range ssl {
+		if row.Ssl {
+			encrypted[row.Pid] = true
+		}
+	}
+	for _, row := range gssapi {
+		if row.Encrypted {
+			encrypted[row.Pid] = true
+		}
+	}
+
+	type connKey struct {
+		usename, clientAddr string
+	}
+
+	groups := map[connKey]*UnencryptedConnections{}
+	data := []*UnencryptedConnections{}
+	for _, row := range activity {
+		if row.ClientAddr == nil || !row.ClientAddr.Valid || encrypted[row.Pid] {
+			continue
+		}
+
+		key := connKey{clientAddr: row.ClientAddr.String}
+		if row.Usename != nil {
+			key.usename = row.Usename.String
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &UnencryptedConnections{Usename: key.usename, ClientAddr: key.clientAddr}
+			groups[key] = group
+			data = append(data, group)
+		}
+		group.Connections++
+		group.Pids = append(group.Pids, row.Pid)
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		if data[i].Connections != data[j].Connections {
+			return data[i].Connections > data[j].Connections
+		}
+		if data[i].Usename != data[j].Usename {
+			return data[i].Usename < data[j].Usename
+		}
+		return data[i].ClientAddr < data[j].ClientAddr
+	})
+
+	result := make([]UnencryptedConnections, 0, len(data))
+	for _, group := range data {
+		result = append(result, *group)
+	}
+	return result
+}
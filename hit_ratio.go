@@ -0,0 +1,167 @@
+package pgstats
+
+import "database/sql"
+
+// HitRatioRow represents buffer cache hit ratio of a database or a relation.
+type HitRatioRow struct {
+	Oid        int64            `json:"oid"`        // OID of a database, table or index
+	Schemaname string           `json:"schemaname"` // Name of the schema of a table or index, empty for databases
+	Name       string           `json:"name"`       // Name of a database, table or index
+	Kind       string           `json:"kind"`       // One of: database, heap, idx, toast, tidx, index
+	Hit        int64            `json:"hit"`        // Number of buffer hits
+	Read       int64            `json:"read"`       // Number of disk blocks read
+	Ratio      *sql.NullFloat64 `json:"ratio"`      // hit / (hit + read), null if there were no block accesses
+	Low        bool             `json:"low"`        // True if Ratio is below the threshold
+}
+
+// DatabaseHitRatios returns cumulative hit ratio of every database from Database rows.
+// Ratios below threshold (in range from 0 to 1) are flagged as low.
+func DatabaseHitRatios(rows []DatabaseRow, threshold float64) []HitRatioRow {
+	return DatabaseHitRatiosDelta(nil, rows, threshold)
+}
+
+// DatabaseHitRatiosDelta returns hit ratio of every database over the interval between two Database fetches.
+// Databases absent in prev or with reset statistics (stats_reset changed or counters decreased) are computed cumulatively.
+func DatabaseHitRatiosDelta(prev, curr []DatabaseRow, threshold float64) []HitRatioRow {
+	before := make(map[int64]DatabaseRow, len(prev))
+	for _, row := range prev {
+		before[row.Datid] = row
+	}
+
+	data := make([]HitRatioRow, 0, len(curr))
+	for _, row := range curr {
+		p, ok := before[row.Datid]
+		if ok && (timeChanged(p.StatsReset, row.StatsReset) ||
+			decreased(p.BlksHit, row.BlksHit) || decreased(p.BlksRead, row.BlksRead)) {
+			p = DatabaseRow{}
+		}
+		data = append(data, newHitRatio(row.Datid, "", row.Datname, "database",
+			counterDelta(p.BlksHit, row.BlksHit), counterDelta(p.BlksRead, row.BlksRead), threshold))
+	}
+	return data
+}
+
+// IoTablesHitRatios returns cumulative hit ratios of heap, indexes, TOAST and TOAST indexes of every table from IoTables rows.
+// Tables without TOAST have no toast and tidx ratios. Ratios below threshold (in range from 0 to 1) are flagged as low.
+func IoTablesHitRatios(rows []IoTablesRow, threshold float64) []HitRatioRow {
+	return IoTablesHitRatiosDelta(nil, rows, threshold)
+}
+
+// IoTablesHitRatiosDelta returns hit ratios of every table over the interval between two IoTables fetches.
+// Tables absent in prev or with reset statistics are computed cumulatively.
+func IoTablesHitRatiosDelta(prev, curr []IoTablesRow, threshold float64) []HitRatioRow {
+	before := make(map[int64]IoTablesRow, len(prev))
+	for _, row := range prev {
+		before[row.Relid] = row
+	}
+
+	data := make([]HitRatioRow, 0, 4*len(curr))
+	for _, row := range curr {
+		// a reset of any counter makes the whole row cumulative, so hits and reads are never mixed
+		p := before[row.Relid]
+		if decreased(p.HeapBlksHit, row.HeapBlksHit) || decreased(p.HeapBlksRead, row.HeapBlksRead) ||
+			decreased(p.IdxBlksHit, row.IdxBlksHit) || decreased(p.IdxBlksRead, row.IdxBlksRead) ||
+			decreased(p.ToastBlksHit, row.ToastBlksHit) || decreased(p.ToastBlksRead, row.ToastBlksRead) ||
+			decreased(p.TidxBlksHit, row.TidxBlksHit) || decreased(p.TidxBlksRead, row.TidxBlksRead) {
+			p = IoTablesRow{}
+		}
+		data = append(data,
+			newHitRatio(row.Relid, row.Schemaname, row.Relname, "heap",
+				counterDelta(p.HeapBlksHit, row.HeapBlksHit), counterDelta(p.HeapBlksRead, row.HeapBlksRead), threshold),
+			newHitRatio(row.Relid, row.Schemaname, row.Relname, "idx",
+				counterDelta(p.IdxBlksHit, row.IdxBlksHit), counterDelta(p.IdxBlksRead, row.IdxBlksRead), threshold),
+		)
+		if isNull(row.ToastBlksHit) && isNull(row.ToastBlksRead) {
+			continue
+		}
+		data = append(data,
+			newHitRatio(row.Relid, row.Schemaname, row.Relname, "toast",
+				counterDelta(p.ToastBlksHit, row.ToastBlksHit), counterDelta(p.ToastBlksRead, row.ToastBlksRead), threshold),
+			newHitRatio(row.Relid, row.Schemaname, row.Relname, "tidx",
+				counterDelta(p.TidxBlksHit, row.TidxBlksHit), counterDelta(p.TidxBlksRead, row.TidxBlksRead), threshold),
+		)
+	}
+	return data
+}
+
+// IoIndexesHitRatios returns cumulative hit ratio of every index from IoIndexes rows.
+// Ratios below threshold (in range from 0 to 1) are flagged as low.
+func IoIndexesHitRatios(rows []IoIndexesRow, threshold float64) []HitRatioRow {
+	return IoIndexesHitRatiosDelta(nil, rows, threshold)
+}
+
+// IoIndexesHitRatiosDelta returns hit ratio of every index over the interval between two IoIndexes fetches.
+// Indexes absent in prev or with reset statistics are computed cumulatively.
+func IoIndexesHitRatiosDelta(prev, curr []IoIndexesRow, threshold float64) []HitRatioRow {
+	before := make(map[int64]IoIndexesRow, len(prev))
+	for _, row := range prev {
+		before[row.Indexrelid] = row
+	}
+
+	data := make([]HitRatioRow, 0, len(curr))
+	for _, row := range curr {
+		p := before[row.Indexrelid]
+		if decreased(p.IdxBlksHit, row.IdxBlksHit) || decreased(p.IdxBlksRead, row.IdxBlksRead) {
+			p = IoIndexesRow{}
+		}
+		data = append(data, newHitRatio(row.Indexrelid, row.Schemaname, row.Indexrelname, "index",
+			counterDelta(p.IdxBlksHit, row.IdxBlksHit), counterDelta(p.IdxBlksRead, row.IdxBlksRead), threshold))
+	}
+	return data
+}
+
+func newHitRatio(oid int64, schemaname, name, kind string, hit, read int64, threshold float64) HitRatioRow {
+	row := HitRatioRow{
+		Oid:        oid,
+		Schemaname: schemaname,
+		Name:       name,
+		Kind:       kind,
+		Hit:        hit,
+		Read:       read,
+		Ratio:      &sql.NullFloat64{},
+	}
+	if total := hit + read; total > 0 {
+		row.Ratio.Float64 = float64(hit) / float64(total)
+		row.Ratio.Valid = true
+		row.Low = row.Ratio.Float64 < threshold
+	}
+	return row
+}
+
+// counterDelta returns increase of a cumulative counter, or its current value if the counter was reset.
+func counterDelta(prev, curr *sql.NullInt64) int64 {
+	if isNull(curr) {
+		return 0
+	}
+	if isNull(prev) || prev.Int64 > curr.Int64 {
+		return curr.Int64
+	}
+	return curr.Int64 - prev.Int64
+}
+
+// floatDelta returns increase of a cumulative float counter, or its current value if the counter was reset.
+func floatDelta(prev, curr *sql.NullFloat64) float64 {
+	if curr == nil || !curr.Valid {
+		return 0
+	}
+	if prev == nil || !prev.Valid || prev.Float64 > curr.Float64 {
+		return curr.Float64
+	}
+	return curr.Float64 - prev.Float64
+}
+
+// decreased reports whether a cumulative counter went down between two fetches, i.e. it was reset.
+func decreased(prev, curr *sql.NullInt64) bool {
+	return !isNull(prev) && (isNull(curr) || curr.Int64 < prev.Int64)
+}
+
+// timeChanged reports whether a timestamp like stats_reset differs between two fetches.
+func timeChanged(prev, curr *sql.NullTime) bool {
+	prevValid := prev != nil && prev.Valid
+	currValid := curr != nil && curr.Valid
+	return prevValid != currValid || (prevValid && !prev.Time.Equal(curr.Time))
+}
+
+func isNull(v *sql.NullInt64) bool {
+	return v == nil || !v.Valid
+}
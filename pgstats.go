@@ -1,17 +1,20 @@
 package pgstats
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 var versionRegex = regexp.MustCompile(`(^9\.\d)|(^\d{2})`)
 
 // Stats provides an access to the Postgres monitoring statistics.
 type Stats struct {
-	db *sql.DB
+	db      *sql.DB
+	noSizes bool
 }
 
 // New creates a new Stats to access Postgres stats.
@@ -30,9 +33,21 @@ func (s *Stats) Close() error {
 	return s.db.Close()
 }
 
+// WithoutSizes returns a copy of Stats which does not call size functions like pg_database_size or pg_relation_size.
+// Size functions can be slow on big catalogs, size fields are null (or 0 for not nullable ones) in this case.
+func (s *Stats) WithoutSizes() *Stats {
+	c := *s
+	c.noSizes = true
+	return &c
+}
+
 func (s *Stats) getVersion() (float64, error) {
+	return s.getVersionContext(context.Background())
+}
+
+func (s *Stats) getVersionContext(ctx context.Context) (float64, error) {
 	const query = "SHOW server_version;"
-	row := s.db.QueryRow(query)
+	row := s.db.QueryRowContext(ctx, query)
 
 	var version string
 	err := row.Scan(&version)
@@ -42,6 +57,57 @@ func (s *Stats) getVersion() (float64, error) {
 	return parseMajorVersion(version)
 }
 
+// getSettingsContext returns values of the given settings from pg_settings.
+// Settings unknown to the server are absent in the result.
+func (s *Stats) getSettingsContext(ctx context.Context, names ...string) (map[string]string, error) {
+	const query = "SELECT setting FROM pg_settings WHERE name = $1"
+
+	res := make(map[string]string, len(names))
+	for _, name := range names {
+		var setting string
+		err := s.db.QueryRowContext(ctx, query, name).Scan(&setting)
+		switch {
+		case err == sql.ErrNoRows:
+			continue
+		case err != nil:
+			return nil, err
+		}
+		res[name] = setting
+	}
+	return res, nil
+}
+
+// getExtensionVersionContext returns version of the extension installed in the current database
+// as major*100+minor, e.g. 110 for 1.10, or 0 if the extension is not installed.
+func (s *Stats) getExtensionVersionContext(ctx context.Context, name string) (int, error) {
+	const query = "SELECT extversion FROM pg_extension WHERE extname = $1"
+
+	var version string
+	err := s.db.QueryRowContext(ctx, query, name).Scan(&version)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+	return parseExtensionVersion(version)
+}
+
+func parseExtensionVersion(s string) (int, error) {
+	parts := strings.SplitN(s, ".", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	var minor int
+	if len(parts) > 1 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, err
+		}
+	}
+	return major*100 + minor, nil
+}
+
 func parseMajorVersion(s string) (float64, error) {
 	v := versionRegex.FindString(s)
 	if v == "" {
@@ -1,10 +1,14 @@
 package pgstats_test
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 
@@ -73,6 +77,42 @@ func isOK(t *testing.T, size int, err error) {
 	}
 }
 
+// serverVersion returns server_version_num of the test server, e.g. 120005 for 12.5.
+func serverVersion(t *testing.T) int {
+	t.Helper()
+
+	var version int
+	noErr(t, testConn.QueryRow("SHOW server_version_num").Scan(&version))
+	return version
+}
+
+// hasSchema checks that every column of the view is mapped to a json tag of the row.
+func hasSchema(t *testing.T, view string, row interface{}) {
+	t.Helper()
+
+	const query = `SELECT attname FROM pg_attribute WHERE attrelid = $1::regclass AND attnum > 0 AND NOT attisdropped`
+
+	rows, err := testConn.Query(query, view)
+	noErr(t, err)
+	defer rows.Close()
+
+	tags := map[string]bool{}
+	typ := reflect.TypeOf(row)
+	for i := 0; i < typ.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("json")
+		tags[strings.Split(tag, ",")[0]] = true
+	}
+
+	for rows.Next() {
+		var column string
+		noErr(t, rows.Scan(&column))
+		if !tags[column] {
+			t.Errorf("column %s of %s is not mapped to %s", column, view, typ.Name())
+		}
+	}
+	noErr(t, rows.Err())
+}
+
 func TestActivity(t *testing.T) {
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
@@ -81,6 +121,106 @@ func TestActivity(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestActivitySchema(t *testing.T) {
+	hasSchema(t, "pg_stat_activity", pgstats.ActivityRow{})
+}
+
+func TestParallelQueries(t *testing.T) {
+	leader := &sql.NullInt64{Int64: 1, Valid: true}
+	rows := []pgstats.ActivityRow{
+		{Pid: 1},
+		{Pid: 2, LeaderPid: leader},
+		{Pid: 3, LeaderPid: leader},
+		{Pid: 4, LeaderPid: &sql.NullInt64{Int64: 10, Valid: true}},
+		{Pid: 5},
+	}
+
+	groups := pgstats.ParallelQueries(rows)
+	if len(groups) != 2 {
+		t.Fatalf("want 2 groups, got %d", len(groups))
+	}
+	if groups[0].Leader == nil || groups[0].Leader.Pid != 1 || len(groups[0].Workers) != 2 {
+		t.Fatalf("unexpected group %+v", groups[0])
+	}
+	if groups[1].Leader != nil || len(groups[1].Workers) != 1 {
+		t.Fatalf("unexpected group %+v", groups[1])
+	}
+}
+
+func TestActivityStatements(t *testing.T) {
+	valid := func(v int64) *sql.NullInt64 { return &sql.NullInt64{Int64: v, Valid: true} }
+	activity := []pgstats.ActivityRow{
+		{Pid: 1, Datid: valid(5), Usesysid: valid(10), QueryID: valid(42)},
+		{Pid: 2, Datid: valid(6), Usesysid: valid(10), QueryID: valid(42)},
+		{Pid: 3},
+	}
+	statements := []pgstats.StatementsRow{
+		{Userid: 10, Dbid: 5, Queryid: 42, Calls: 3, Toplevel: &sql.NullBool{Bool: false, Valid: true}},
+		{Userid: 10, Dbid: 5, Queryid: 42, Calls: 7, Toplevel: &sql.NullBool{Bool: true, Valid: true}},
+	}
+
+	joined := pgstats.ActivityStatements(activity, statements)
+	if len(joined) != 3 {
+		t.Fatalf("want 3 rows, got %d", len(joined))
+	}
+	if joined[0].Statement == nil || joined[0].Statement.Calls != 7 {
+		t.Fatalf("want statement for pid 1, got %+v", joined[0].Statement)
+	}
+	if joined[1].Statement != nil || joined[2].Statement != nil {
+		t.Fatal("want no statement for pids 2 and 3")
+	}
+}
+
+func TestActivityHistory(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	history := stats.NewActivityHistory(time.Second, time.Minute, 2)
+	now := time.Now()
+
+	history.Add([]pgstats.ActivityRow{{Pid: 1}, {Pid: 2}}, now.Add(-2*time.Minute))
+	history.Add([]pgstats.ActivityRow{{Pid: 1}, {Pid: 2}, {Pid: 3}}, now)
+
+	all := history.Query(pgstats.ActivityFilter{})
+	if len(all) != 2 || all[0].Pid != 2 || all[1].Pid != 3 {
+		t.Fatalf("want the last 2 samples, got %+v", all)
+	}
+
+	one := history.Query(pgstats.ActivityFilter{Pid: 3})
+	if len(one) != 1 {
+		t.Fatalf("want 1 sample, got %d", len(one))
+	}
+}
+
+func TestActivityHistoryExpire(t *testing.T) {
+	history := (&pgstats.Stats{}).NewActivityHistory(0, time.Minute, 3)
+	now := time.Now()
+
+	history.Add([]pgstats.ActivityRow{{Pid: 1}, {Pid: 2}}, now.Add(-time.Hour))
+	if got := history.Query(pgstats.ActivityFilter{}); len(got) != 0 {
+		t.Fatalf("want samples older than retention to expire, got %+v", got)
+	}
+
+	history.Add([]pgstats.ActivityRow{{Pid: 3}, {Pid: 4}}, now.Add(-30*time.Second))
+	history.Add([]pgstats.ActivityRow{{Pid: 5}, {Pid: 6}}, now)
+	got := history.Query(pgstats.ActivityFilter{})
+	if len(got) != 3 || got[0].Pid != 4 || got[2].Pid != 6 {
+		t.Fatalf("want the last 3 samples, got %+v", got)
+	}
+
+	var buf strings.Builder
+	noErr(t, history.Dump(&buf, pgstats.ActivityFilter{From: now}))
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Fatalf("want 2 dumped samples, got %d", lines)
+	}
+
+	empty := (&pgstats.Stats{}).NewActivityHistory(-time.Second, -time.Minute, -1)
+	empty.Add([]pgstats.ActivityRow{{Pid: 1}}, now)
+	if got := empty.Query(pgstats.ActivityFilter{}); len(got) != 0 {
+		t.Fatalf("want no samples with negative capacity, got %+v", got)
+	}
+}
+
 func TestArchiver(t *testing.T) {
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
@@ -89,6 +229,50 @@ func TestArchiver(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestAutovacuum(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.Autovacuum(context.Background())
+	isOK(t, 1, err)
+}
+
+func TestAutovacuumSettingsWithReloptions(t *testing.T) {
+	global := pgstats.AutovacuumSettings{
+		Enabled:            true,
+		VacuumThreshold:    50,
+		VacuumScaleFactor:  0.2,
+		AnalyzeThreshold:   50,
+		AnalyzeScaleFactor: 0.1,
+	}
+
+	got := global.WithReloptions([]string{"autovacuum_enabled=false", "autovacuum_vacuum_scale_factor=0.01", "fillfactor=90"})
+	want := global
+	want.Enabled = false
+	want.VacuumScaleFactor = 0.01
+
+	if got != want {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+
+	global.Enabled = false
+	got = global.WithReloptions([]string{"autovacuum_enabled=true", "autovacuum_vacuum_insert_threshold=100"})
+	if got.Enabled || got.VacuumInsertThreshold != 100 {
+		t.Fatalf("want disabled autovacuum with insert threshold 100, got %+v", got)
+	}
+}
+
+func TestBloat(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.TableBloat(context.Background(), false)
+	isOK(t, 1, err)
+
+	_, err = stats.IndexBloat(context.Background())
+	isOK(t, 1, err)
+}
+
 func TestBgWriter(t *testing.T) {
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
@@ -97,6 +281,101 @@ func TestBgWriter(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestConfigAdvisor(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.ConfigAdvisor(context.Background())
+	isOK(t, 1, err)
+}
+
+func TestAdviseConfig(t *testing.T) {
+	in := pgstats.ConfigAdvisorInput{
+		Settings: []pgstats.SettingsRow{
+			{Name: "shared_buffers", Setting: "16384", Unit: &sql.NullString{String: "8kB", Valid: true}},
+			{Name: "work_mem", Setting: "4096", Unit: &sql.NullString{String: "kB", Valid: true}},
+			{Name: "track_io_timing", Setting: "off", Bool: &sql.NullBool{Bool: false, Valid: true}},
+			{Name: "pg_stat_statements.max", Setting: "5000"},
+		},
+		Databases: []pgstats.DatabaseRow{{
+			BlksHit:    &sql.NullInt64{Int64: 90, Valid: true},
+			BlksRead:   &sql.NullInt64{Int64: 10, Valid: true},
+			TempFiles:  &sql.NullInt64{Int64: 3, Valid: true},
+			TempBytes:  &sql.NullInt64{Int64: 8 << 20, Valid: true},
+			XactCommit: &sql.NullInt64{Int64: 2, Valid: true},
+		}},
+		StatementsDealloc: &sql.NullInt64{Int64: 7, Valid: true},
+	}
+
+	advices := pgstats.AdviseConfig(in)
+	want := []string{"shared_buffers", "work_mem", "track_io_timing", "pg_stat_statements.max"}
+	if len(advices) != len(want) {
+		t.Fatalf("want %d advices, got %+v", len(want), advices)
+	}
+	for i, advice := range advices {
+		if advice.Setting != want[i] {
+			t.Fatalf("want advice for %s, got %+v", want[i], advice)
+		}
+	}
+}
+
+func TestConnections(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	res, err := stats.Connections(context.Background())
+	isOK(t, len(res.Groups), err)
+}
+
+func TestBgWriterSettings(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.BgWriterSettings(context.Background())
+	isOK(t, 1, err)
+}
+
+func TestAnalyzeBgWriter(t *testing.T) {
+	prev := pgstats.BgWriterView{
+		CheckpointsTimed:  &sql.NullInt64{Int64: 10, Valid: true},
+		CheckpointsReq:    &sql.NullInt64{Int64: 0, Valid: true},
+		BuffersCheckpoint: &sql.NullInt64{Int64: 100, Valid: true},
+		BuffersClean:      &sql.NullInt64{Int64: 100, Valid: true},
+		BuffersBackend:    &sql.NullInt64{Int64: 100, Valid: true},
+		MaxWrittenClean:   &sql.NullInt64{Int64: 0, Valid: true},
+	}
+	curr := pgstats.BgWriterView{
+		CheckpointsTimed:  &sql.NullInt64{Int64: 12, Valid: true},
+		CheckpointsReq:    &sql.NullInt64{Int64: 2, Valid: true},
+		BuffersCheckpoint: &sql.NullInt64{Int64: 200, Valid: true},
+		BuffersClean:      &sql.NullInt64{Int64: 150, Valid: true},
+		BuffersBackend:    &sql.NullInt64{Int64: 150, Valid: true},
+		MaxWrittenClean:   &sql.NullInt64{Int64: 3, Valid: true},
+	}
+	settings := pgstats.BgWriterSettings{MaxWalSize: "1GB", CheckpointTimeout: 5 * time.Minute, BgwriterLruMaxpages: 100}
+
+	report := pgstats.AnalyzeBgWriter(prev, curr, settings)
+	if report.RequestedRatio != 0.5 {
+		t.Fatalf("want requested ratio 0.5, got %v", report.RequestedRatio)
+	}
+	if report.BackendRatio != 0.25 {
+		t.Fatalf("want backend ratio 0.25, got %v", report.BackendRatio)
+	}
+	if len(report.Warnings) != 3 {
+		t.Fatalf("want 3 warnings, got %v", report.Warnings)
+	}
+
+	prev.CheckpointsDone = &sql.NullInt64{Int64: 5, Valid: true}
+	prev.CheckpointWriteTime = &sql.NullFloat64{Float64: 1000, Valid: true}
+	curr.CheckpointsDone = &sql.NullInt64{Int64: 7, Valid: true}
+	curr.CheckpointWriteTime = &sql.NullFloat64{Float64: 3000, Valid: true}
+
+	report = pgstats.AnalyzeBgWriter(prev, curr, settings)
+	if report.AvgCheckpointWriteTime != 1000 {
+		t.Fatalf("want average write time 1000 per done checkpoint, got %v", report.AvgCheckpointWriteTime)
+	}
+}
+
 func TestDatabaseConflicts(t *testing.T) {
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
@@ -113,6 +392,30 @@ func TestDatabase(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestDatabaseSchema(t *testing.T) {
+	hasSchema(t, "pg_stat_database", pgstats.DatabaseRow{})
+}
+
+func TestTablesSchema(t *testing.T) {
+	hasSchema(t, "pg_stat_all_tables", pgstats.TablesRow{})
+	hasSchema(t, "pg_stat_all_indexes", pgstats.IndexesRow{})
+	hasSchema(t, "pg_stat_xact_all_tables", pgstats.XactTablesRow{})
+}
+
+func TestDatabaseWithoutSizes(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	dbs, err := stats.WithoutSizes().Database()
+	isOK(t, len(dbs), err)
+
+	for _, db := range dbs {
+		if db.Size != nil {
+			t.Fatalf("size of %s must be null", db.Datname)
+		}
+	}
+}
+
 func TestFunctions(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
@@ -125,6 +428,50 @@ func TestFunctions(t *testing.T) {
 	isOK(t, len(xfuncs), err)
 }
 
+func TestHitRatios(t *testing.T) {
+	prev := []pgstats.DatabaseRow{
+		{Datid: 1, BlksHit: &sql.NullInt64{Int64: 90, Valid: true}, BlksRead: &sql.NullInt64{Int64: 10, Valid: true}},
+		{Datid: 2, BlksHit: &sql.NullInt64{Int64: 10, Valid: true}, BlksRead: &sql.NullInt64{Int64: 10, Valid: true}},
+		{Datid: 4, BlksHit: &sql.NullInt64{Int64: 10, Valid: true}, BlksRead: &sql.NullInt64{Int64: 10, Valid: true}},
+	}
+	curr := []pgstats.DatabaseRow{
+		{Datid: 1, BlksHit: &sql.NullInt64{Int64: 100, Valid: true}, BlksRead: &sql.NullInt64{Int64: 20, Valid: true}},
+		{Datid: 2, BlksHit: &sql.NullInt64{Int64: 10, Valid: true}, BlksRead: &sql.NullInt64{Int64: 10, Valid: true}},
+		{Datid: 3},
+		{
+			Datid:      4,
+			BlksHit:    &sql.NullInt64{Int64: 30, Valid: true},
+			BlksRead:   &sql.NullInt64{Int64: 10, Valid: true},
+			StatsReset: &sql.NullTime{Time: time.Now(), Valid: true},
+		},
+	}
+
+	total := pgstats.DatabaseHitRatios(curr, 0.9)
+	if got := total[0].Ratio.Float64; got != 100.0/120 {
+		t.Fatalf("want cumulative ratio %v, got %v", 100.0/120, got)
+	}
+
+	delta := pgstats.DatabaseHitRatiosDelta(prev, curr, 0.9)
+	if got := delta[0].Ratio.Float64; got != 0.5 || !delta[0].Low {
+		t.Fatalf("want low interval ratio 0.5, got %v", got)
+	}
+	if delta[1].Ratio.Valid || delta[1].Low {
+		t.Fatal("ratio without block accesses must be null")
+	}
+	if delta[2].Ratio.Valid {
+		t.Fatal("ratio without statistics must be null")
+	}
+	if got := delta[3].Ratio.Float64; got != 0.75 {
+		t.Fatalf("want cumulative ratio 0.75 after reset, got %v", got)
+	}
+
+	prevIdx := []pgstats.IoIndexesRow{{Indexrelid: 1, IdxBlksHit: &sql.NullInt64{Int64: 100, Valid: true}, IdxBlksRead: &sql.NullInt64{Int64: 10, Valid: true}}}
+	currIdx := []pgstats.IoIndexesRow{{Indexrelid: 1, IdxBlksHit: &sql.NullInt64{Int64: 60, Valid: true}, IdxBlksRead: &sql.NullInt64{Int64: 20, Valid: true}}}
+	if got := pgstats.IoIndexesHitRatiosDelta(prevIdx, currIdx, 0.9)[0].Ratio.Float64; got != 0.75 {
+		t.Fatalf("want cumulative ratio 0.75 after partial reset, got %v", got)
+	}
+}
+
 func TestIndex(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
@@ -140,6 +487,14 @@ func TestIndex(t *testing.T) {
 	isOK(t, len(usr), err)
 }
 
+func TestIndexAdvisor(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.IndexAdvisor(context.Background())
+	isOK(t, 1, err)
+}
+
 func TestIoIndex(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
@@ -163,6 +518,77 @@ func TestProgressVacuum(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestRecoveryStatus(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.RecoveryStatus()
+	isOK(t, 1, err)
+}
+
+func TestPreparedXacts(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.PreparedXacts(context.Background())
+	isOK(t, 1, err)
+}
+
+func TestProgress(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.Progress(context.Background())
+	isOK(t, 1, err)
+}
+
+func TestProgressMaintenance(t *testing.T) {
+	t.Skip()
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.ProgressAnalyze()
+	isOK(t, 1, err)
+
+	_, err = stats.ProgressCreateIndex()
+	isOK(t, 1, err)
+
+	_, err = stats.ProgressCluster()
+	isOK(t, 1, err)
+
+	_, err = stats.ProgressCopy()
+	isOK(t, 1, err)
+
+	_, err = stats.ProgressBasebackup()
+	isOK(t, 1, err)
+}
+
+func TestVacuumSampler(t *testing.T) {
+	sampler := pgstats.NewVacuumSampler()
+	now := time.Now()
+
+	row := pgstats.ProgressVacuumRow{
+		Pid:             1,
+		Relid:           42,
+		Phase:           "scanning heap",
+		HeapBlksTotal:   &sql.NullInt64{Int64: 1000, Valid: true},
+		HeapBlksScanned: &sql.NullInt64{Int64: 100, Valid: true},
+	}
+	est := sampler.Add([]pgstats.ProgressVacuumRow{row}, now)
+	if est[0].RemainingSeconds.Valid {
+		t.Fatal("estimate must be unknown after the first observation")
+	}
+
+	row.HeapBlksScanned = &sql.NullInt64{Int64: 200, Valid: true}
+	est = sampler.Add([]pgstats.ProgressVacuumRow{row}, now.Add(10*time.Second))
+	if got := est[0].BlocksPerSecond.Float64; got != 10 {
+		t.Fatalf("want 10 blocks per second, got %v", got)
+	}
+	if got := est[0].RemainingSeconds.Float64; got != 80 {
+		t.Fatalf("want 80 seconds remaining, got %v", got)
+	}
+}
+
 func TestReplication(t *testing.T) {
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
@@ -171,6 +597,22 @@ func TestReplication(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestReplicationLag(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.ReplicationLag(context.Background())
+	isOK(t, 1, err)
+}
+
+func TestReplicationSlots(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.ReplicationSlots()
+	isOK(t, 1, err)
+}
+
 func TestSequences(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
@@ -186,12 +628,101 @@ func TestSequences(t *testing.T) {
 	isOK(t, len(usr), err)
 }
 
+func TestSettings(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	settings, err := stats.Settings(context.Background())
+	isOK(t, len(settings), err)
+
+	for _, setting := range settings {
+		switch setting.Name {
+		case "shared_buffers":
+			if !setting.Bytes.Valid || setting.Bytes.Int64 <= 0 {
+				t.Fatalf("want shared_buffers in bytes, got %+v", setting)
+			}
+		case "checkpoint_timeout":
+			if !setting.Duration.Valid || setting.Duration.Int64 <= 0 {
+				t.Fatalf("want checkpoint_timeout duration, got %+v", setting)
+			}
+		case "wal_level":
+			if !setting.String.Valid || setting.String.String == "" {
+				t.Fatalf("want wal_level enum value, got %+v", setting)
+			}
+		}
+	}
+}
+
+func TestDiffSettings(t *testing.T) {
+	prev := []pgstats.SettingsRow{
+		{Name: "work_mem", Setting: "4096"},
+		{Name: "max_connections", Setting: "100"},
+		{Name: "removed", Setting: "x"},
+	}
+	curr := []pgstats.SettingsRow{
+		{Name: "work_mem", Setting: "8192"},
+		{Name: "max_connections", Setting: "100", PendingRestart: true},
+		{Name: "added", Setting: "y"},
+	}
+
+	changes := pgstats.DiffSettings(prev, curr)
+	if len(changes) != 4 {
+		t.Fatalf("want 4 changes, got %+v", changes)
+	}
+	if changes[2].Old != nil || changes[3].New != nil {
+		t.Fatalf("want added and removed settings, got %+v", changes)
+	}
+}
+
+func TestSlru(t *testing.T) {
+	t.Skip()
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	slru, err := stats.Slru()
+	isOK(t, len(slru), err)
+}
+
 func TestSsl(t *testing.T) {
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
 
 	_, err = stats.Ssl()
 	isOK(t, 1, err)
+	hasSchema(t, "pg_stat_ssl", pgstats.SslRow{})
+}
+
+func TestGssapi(t *testing.T) {
+	if serverVersion(t) < 120000 {
+		t.Skip("pg_stat_gssapi requires PostgreSQL 12")
+	}
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.Gssapi()
+	isOK(t, 1, err)
+	hasSchema(t, "pg_stat_gssapi", pgstats.GssapiRow{})
+}
+
+func TestAuditEncryption(t *testing.T) {
+	text := func(v string) *sql.NullString { return &sql.NullString{String: v, Valid: true} }
+	activity := []pgstats.ActivityRow{
+		{Pid: 1, Usename: text("app"), ClientAddr: text("10.0.0.1")},
+		{Pid: 2, Usename: text("app"), ClientAddr: text("10.0.0.1")},
+		{Pid: 3, Usename: text("app"), ClientAddr: text("10.0.0.2")},
+		{Pid: 4, Usename: text("admin"), ClientAddr: text("10.0.0.3")},
+		{Pid: 5, Usename: text("admin")},
+	}
+	ssl := []pgstats.SslRow{{Pid: 3, Ssl: true}}
+	gssapi := []pgstats.GssapiRow{{Pid: 4, Encrypted: true}}
+
+	audit := pgstats.AuditEncryption(activity, ssl, gssapi)
+	if len(audit) != 1 {
+		t.Fatalf("want 1 group, got %+v", audit)
+	}
+	if audit[0].Usename != "app" || audit[0].ClientAddr != "10.0.0.1" || audit[0].Connections != 2 {
+		t.Fatalf("unexpected group %+v", audit[0])
+	}
 }
 
 func TestStatements(t *testing.T) {
@@ -211,6 +742,67 @@ func TestSubscription(t *testing.T) {
 	isOK(t, 1, err)
 }
 
+func TestSubscriptionStats(t *testing.T) {
+	if serverVersion(t) < 150000 {
+		t.Skip("pg_stat_subscription_stats requires PostgreSQL 15")
+	}
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.SubscriptionStats()
+	isOK(t, 1, err)
+	hasSchema(t, "pg_stat_subscription_stats", pgstats.SubscriptionStatsRow{})
+}
+
+func TestSubscriptionRel(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	_, err = stats.SubscriptionRel()
+	isOK(t, 1, err)
+}
+
+func TestSubscriptionLag(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *sql.NullTime { return &sql.NullTime{Time: now.Add(-d), Valid: true} }
+	rows := []pgstats.SubscriptionRow{
+		{
+			Subid:              &sql.NullInt64{Int64: 1, Valid: true},
+			Subname:            &sql.NullString{String: "sub", Valid: true},
+			ReceivedLsn:        &sql.NullInt64{Int64: 300, Valid: true},
+			LatestEndLsn:       &sql.NullInt64{Int64: 100, Valid: true},
+			LatestEndTime:      at(10 * time.Second),
+			LastMsgSendTime:    at(3 * time.Second),
+			LastMsgReceiptTime: at(time.Second),
+		},
+		{Subid: &sql.NullInt64{Int64: 1, Valid: true}, Relid: &sql.NullInt64{Int64: 42, Valid: true}},
+		{Subid: &sql.NullInt64{Int64: 1, Valid: true}, LeaderPid: &sql.NullInt64{Int64: 7, Valid: true}},
+	}
+
+	lag := pgstats.SubscriptionLag(rows, now)
+	if len(lag) != 1 {
+		t.Fatalf("want 1 row, got %d", len(lag))
+	}
+	if lag[0].Subname != "sub" || lag[0].FeedbackPendingBytes.Int64 != 200 {
+		t.Fatalf("unexpected lag %+v", lag[0])
+	}
+	if lag[0].LagSeconds.Float64 != 10 || lag[0].TransportDelaySeconds.Float64 != 2 {
+		t.Fatalf("unexpected lag seconds %v and %v", lag[0].LagSeconds.Float64, lag[0].TransportDelaySeconds.Float64)
+	}
+}
+
+func TestSizes(t *testing.T) {
+	t.Skip()
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	tables, err := stats.UserTablesWithSizes()
+	isOK(t, len(tables), err)
+
+	indexes, err := stats.UserIndexesWithSizes()
+	isOK(t, len(indexes), err)
+}
+
 func TestTables(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
@@ -226,15 +818,45 @@ func TestTables(t *testing.T) {
 	isOK(t, len(usr), err)
 }
 
+func TestWaitSampler(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	sampler := stats.NewWaitSampler(10 * time.Millisecond)
+	sampler.Start(context.Background())
+	time.Sleep(100 * time.Millisecond)
+	noErr(t, sampler.Stop())
+
+	profile := sampler.Profile()
+	if profile.Samples == 0 {
+		t.Fatal("No samples collected")
+	}
+}
+
 func TestWalReceiver(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
 	noErr(t, err)
 
 	_, err = stats.WalReceiver()
+	if err == pgstats.ErrNotStandby {
+		err = nil
+	}
 	isOK(t, 1, err)
 }
 
+func TestWalReceiverSchema(t *testing.T) {
+	hasSchema(t, "pg_stat_wal_receiver", pgstats.WalReceiverView{})
+}
+
+func TestWraparound(t *testing.T) {
+	stats, err := pgstats.New(testConn)
+	noErr(t, err)
+
+	res, err := stats.Wraparound(context.Background(), 5)
+	isOK(t, len(res.Databases), err)
+}
+
 func TestXactTables(t *testing.T) {
 	t.Skip()
 	stats, err := pgstats.New(testConn)
@@ -0,0 +1,93 @@
+package pgstats
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultPollInterval is used instead of a non-positive interval, which time.NewTicker does not accept.
+const defaultPollInterval = time.Second
+
+// pollInterval returns interval if it is positive, defaultPollInterval otherwise.
+func pollInterval(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return defaultPollInterval
+	}
+	return interval
+}
+
+// poller calls a function every interval in a background goroutine.
+type poller struct {
+	mu     sync.Mutex
+	err    error
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// start starts polling until ctx is done or stop is called, does nothing if polling is already running.
+func (p *poller) start(ctx context.Context, interval time.Duration, poll func(ctx context.Context) error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done != nil {
+		return
+	}
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.done = make(chan struct{})
+
+	go p.run(ctx, interval, poll, p.done)
+}
+
+// stop stops polling, waits for the background goroutine to exit and returns the last error.
+func (p *poller) stop() error {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.mu.Unlock()
+
+	if done != nil {
+		cancel()
+		<-done
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// lastErr returns the last error of polling, if any.
+func (p *poller) lastErr() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *poller) run(ctx context.Context, interval time.Duration, poll func(ctx context.Context) error, done chan struct{}) {
+	// allow start again once polling is over, even if it was ended by the caller's ctx
+	defer func() {
+		p.mu.Lock()
+		if p.done == done {
+			p.cancel()
+			p.cancel, p.done = nil, nil
+		}
+		p.mu.Unlock()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := poll(ctx); err != nil && ctx.Err() == nil {
+			p.mu.Lock()
+			p.err = err
+			p.mu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,13 +1,16 @@
 package pgstats
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 // Activity returns rows from a `pg_stat_activity` view.
 // The pg_stat_activity module provides a means for information related to the current activity of that process, such as state and current query.
 //
 // SeeL https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-ACTIVITY-VIEW
 func (s *Stats) Activity() ([]ActivityRow, error) {
-	return s.fetchActivity()
+	return s.fetchActivity(context.Background())
 }
 
 // ActivityRow represents schema of pg_stat_activity view
@@ -25,32 +28,37 @@ type ActivityRow struct {
 	XactStart       *sql.NullTime   `json:"xact_start"`       // Time when this process' current transaction was started, or null if no transaction is active.
 	QueryStart      *sql.NullTime   `json:"query_start"`      // Time when the currently active query was started, or if state is not active, when the last query was started
 	StateChange     *sql.NullTime   `json:"state_change"`     // ime when the state was last changed
-	WaitEventType   *sql.NullString `json:"wait_event_type"`  // The type of event for which the backend is waiting, if any; otherwise NULL. Supported since PostgreSQL 9.6.
+	WaitEventType   *sql.NullString `json:"wait_event_type"`  // The type of event for which the backend is waiting, if any; otherwise NULL. Lock if waiting before PostgreSQL 9.6.
 	WaitEvent       *sql.NullString `json:"wait_event"`       // Wait event name if backend is currently waiting, otherwise NULL. Supported since PostgreSQL 9.6.
-	Waiting         *sql.NullBool   `json:"waiting"`          // True if this backend is currently waiting on a lock. Supported until PostgreSQL 9.5 (inclusive).
+	Waiting         *sql.NullBool   `json:"waiting"`          // True if this backend is currently waiting on a lock. Derived from wait_event_type since PostgreSQL 9.6.
 	State           *sql.NullString `json:"state"`            // Current overall state of this backend. See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-ACTIVITY-VIEW
 	BackendXid      *sql.NullInt64  `json:"backend_xid"`      // Top-level transaction identifier of this backend, if any.
 	BackendXmin     *sql.NullInt64  `json:"backend_xmin"`     // The current backend's xmin horizon.
 	Query           *sql.NullString `json:"query"`            // Text of this backend's most recent query.
-	BackendType     *sql.NullString `json:"backend_type"`     // Type of current backend.
+	BackendType     *sql.NullString `json:"backend_type"`     // Type of current backend. Supported since PostgreSQL 10.
+	LeaderPid       *sql.NullInt64  `json:"leader_pid"`       // Process ID of the parallel group leader if this process is a parallel query worker. Supported since PostgreSQL 13.
+	QueryID         *sql.NullInt64  `json:"query_id"`         // Identifier of this backend's most recent query, if compute_query_id is enabled. Supported since PostgreSQL 14.
 }
 
-func (s *Stats) fetchActivity() ([]ActivityRow, error) {
-	version, err := s.getVersion()
+func (s *Stats) fetchActivity(ctx context.Context) ([]ActivityRow, error) {
+	version, err := s.getVersionContext(ctx)
 	switch {
 	case err != nil:
 		return nil, err
+	case version >= 14:
+		return s.fetchActivityQuery(ctx, activityQuery14)
+	case version >= 13:
+		return s.fetchActivityQuery(ctx, activityQuery13)
 	case version > 9.6:
-		return s.fetchActivity10()
+		return s.fetchActivityQuery(ctx, activityQuery10)
 	case version == 9.6:
-		return s.fetchActivity96()
+		return s.fetchActivityQuery(ctx, activityQuery96)
 	default:
-		return s.fetchActivity95()
+		return s.fetchActivityQuery(ctx, activityQuery95)
 	}
 }
 
-func (s *Stats) fetchActivity10() ([]ActivityRow, error) {
-	const query = `SELECT
+const activityQuery14 = `SELECT
 	datid,
 	datname,
 	pid,
@@ -66,55 +74,17 @@ func (s *Stats) fetchActivity10() ([]ActivityRow, error) {
 	state_change,
 	wait_event_type,
 	wait_event,
+	COALESCE(wait_event_type = 'Lock', false),
 	state,
 	backend_xid,
 	backend_xmin,
 	query,
-	backend_type
+	backend_type,
+	leader_pid,
+	query_id
 	FROM pg_stat_activity`
 
-	rows, err := s.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	data := []ActivityRow{}
-	for rows.Next() {
-		var row ActivityRow
-
-		err := rows.Scan(
-			&row.Datid,
-			&row.Datname,
-			&row.Pid,
-			&row.Usesysid,
-			&row.Usename,
-			&row.ApplicationName,
-			&row.ClientAddr,
-			&row.ClientHostname,
-			&row.ClientPort,
-			&row.BackendStart,
-			&row.XactStart,
-			&row.QueryStart,
-			&row.StateChange,
-			&row.WaitEventType,
-			&row.WaitEvent,
-			&row.State,
-			&row.BackendXid,
-			&row.BackendXmin,
-			&row.Query,
-			&row.BackendType,
-		)
-		if err != nil {
-			return nil, err
-		}
-		data = append(data, row)
-	}
-	return data, rows.Err()
-}
-
-func (s *Stats) fetchActivity96() ([]ActivityRow, error) {
-	const query = `SELECT
+const activityQuery13 = `SELECT
 	datid,
 	datname,
 	pid,
@@ -130,53 +100,70 @@ func (s *Stats) fetchActivity96() ([]ActivityRow, error) {
 	state_change,
 	wait_event_type,
 	wait_event,
+	COALESCE(wait_event_type = 'Lock', false),
 	state,
 	backend_xid,
 	backend_xmin,
-	query
+	query,
+	backend_type,
+	leader_pid,
+	NULL
 	FROM pg_stat_activity`
 
-	rows, err := s.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	data := []ActivityRow{}
-	for rows.Next() {
-		var row ActivityRow
+const activityQuery10 = `SELECT
+	datid,
+	datname,
+	pid,
+	usesysid,
+	usename,
+	application_name,
+	client_addr,
+	client_hostname,
+	client_port,
+	backend_start,
+	xact_start,
+	query_start,
+	state_change,
+	wait_event_type,
+	wait_event,
+	COALESCE(wait_event_type = 'Lock', false),
+	state,
+	backend_xid,
+	backend_xmin,
+	query,
+	backend_type,
+	NULL,
+	NULL
+	FROM pg_stat_activity`
 
-		err := rows.Scan(
-			&row.Datid,
-			&row.Datname,
-			&row.Pid,
-			&row.Usesysid,
-			&row.Usename,
-			&row.ApplicationName,
-			&row.ClientAddr,
-			&row.ClientHostname,
-			&row.ClientPort,
-			&row.BackendStart,
-			&row.XactStart,
-			&row.QueryStart,
-			&row.StateChange,
-			&row.WaitEventType,
-			&row.WaitEvent,
-			&row.State,
-			&row.BackendXid,
-			&row.BackendXmin,
-			&row.Query,
-		)
-		if err != nil {
-			return nil, err
-		}
-		data = append(data, row)
-	}
-	return data, rows.Err()
-}
+const activityQuery96 = `SELECT
+	datid,
+	datname,
+	pid,
+	usesysid,
+	usename,
+	application_name,
+	client_addr,
+	client_hostname,
+	client_port,
+	backend_start,
+	xact_start,
+	query_start,
+	state_change,
+	wait_event_type,
+	wait_event,
+	COALESCE(wait_event_type = 'Lock', false),
+	state,
+	backend_xid,
+	backend_xmin,
+	query,
+	NULL,
+	NULL,
+	NULL
+	FROM pg_stat_activity`
 
-func (s *Stats) fetchActivity95() ([]ActivityRow, error) {
-	const query = `SELECT
+// activityQuery95 reports lock waits with the Lock wait event type as PostgreSQL 9.6+ does.
+const activityQuery95 = `SELECT
 	datid,
 	datname,
 	pid,
@@ -190,14 +177,20 @@ func (s *Stats) fetchActivity95() ([]ActivityRow, error) {
 	xact_start,
 	query_start,
 	state_change,
+	CASE WHEN waiting THEN 'Lock' END,
+	NULL,
 	waiting,
 	state,
 	backend_xid,
 	backend_xmin,
-	query
+	query,
+	NULL,
+	NULL,
+	NULL
 	FROM pg_stat_activity`
 
-	rows, err := s.db.Query(query)
+func (s *Stats) fetchActivityQuery(ctx context.Context, query string) ([]ActivityRow, error) {
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -221,11 +214,16 @@ func (s *Stats) fetchActivity95() ([]ActivityRow, error) {
 			&row.XactStart,
 			&row.QueryStart,
 			&row.StateChange,
+			&row.WaitEventType,
+			&row.WaitEvent,
 			&row.Waiting,
 			&row.State,
 			&row.BackendXid,
 			&row.BackendXmin,
 			&row.Query,
+			&row.BackendType,
+			&row.LeaderPid,
+			&row.QueryID,
 		)
 		if err != nil {
 			return nil, err
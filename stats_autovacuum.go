@@ -0,0 +1,255 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Autovacuum returns autovacuum forecast for every user table.
+// Thresholds are computed the same way as autovacuum daemon does: from global settings
+// overridden by per-table storage parameters (reloptions).
+//
+// See: https://www.postgresql.org/docs/current/routine-vacuuming.html#AUTOVACUUM
+func (s *Stats) Autovacuum(ctx context.Context) ([]AutovacuumRow, error) {
+	return s.fetchAutovacuum(ctx)
+}
+
+// AutovacuumSettings represents autovacuum settings applied to a table.
+type AutovacuumSettings struct {
+	Enabled                 bool    `json:"autovacuum_enabled"`                    // Whether autovacuum is enabled for the table, false if it is disabled globally
+	VacuumThreshold         int64   `json:"autovacuum_vacuum_threshold"`           // Minimum number of updated or deleted tuples needed to trigger a VACUUM
+	VacuumScaleFactor       float64 `json:"autovacuum_vacuum_scale_factor"`        // Fraction of the table size to add to autovacuum_vacuum_threshold
+	VacuumInsertThreshold   int64   `json:"autovacuum_vacuum_insert_threshold"`    // Minimum number of inserted tuples needed to trigger a VACUUM, -1 if disabled or before PostgreSQL 13
+	VacuumInsertScaleFactor float64 `json:"autovacuum_vacuum_insert_scale_factor"` // Fraction of the table size to add to autovacuum_vacuum_insert_threshold
+	AnalyzeThreshold        int64   `json:"autovacuum_analyze_threshold"`          // Minimum number of inserted, updated or deleted tuples needed to trigger an ANALYZE
+	AnalyzeScaleFactor      float64 `json:"autovacuum_analyze_scale_factor"`       // Fraction of the table size to add to autovacuum_analyze_threshold
+}
+
+// AutovacuumRow represents autovacuum forecast of a table.
+type AutovacuumRow struct {
+	Relid            int64              `json:"relid"`               // OID of a table
+	Schemaname       string             `json:"schemaname"`          // Name of the schema that this table is in
+	Relname          string             `json:"relname"`             // Name of this table
+	Settings         AutovacuumSettings `json:"settings"`            // Autovacuum settings applied to this table
+	Reltuples        float64            `json:"reltuples"`           // Number of live rows in the table as estimated by the planner
+	NDeadTup         int64              `json:"n_dead_tup"`          // Estimated number of dead rows
+	NModSinceAnalyze int64              `json:"n_mod_since_analyze"` // Estimated number of rows modified since this table was last analyzed
+	NInsSinceVacuum  int64              `json:"n_ins_since_vacuum"`  // Estimated number of rows inserted since this table was last vacuumed, 0 before PostgreSQL 13
+	VacuumThreshold  float64            `json:"vacuum_threshold"`    // Number of dead rows which triggers autovacuum
+	VacuumPercent    float64            `json:"vacuum_percent"`      // Percent of the vacuum threshold reached
+	InsertThreshold  float64            `json:"insert_threshold"`    // Number of inserted rows which triggers autovacuum, -1 if insert-triggered vacuum is disabled
+	InsertPercent    float64            `json:"insert_percent"`      // Percent of the insert threshold reached
+	AnalyzeThreshold float64            `json:"analyze_threshold"`   // Number of modified rows which triggers autoanalyze
+	AnalyzePercent   float64            `json:"analyze_percent"`     // Percent of the analyze threshold reached
+	VacuumOverdue    bool               `json:"vacuum_overdue"`      // True if the vacuum or insert threshold is exceeded but the table is not vacuumed yet, false if autovacuum is disabled
+	AnalyzeOverdue   bool               `json:"analyze_overdue"`     // True if the analyze threshold is exceeded but the table is not analyzed yet, false if autovacuum is disabled
+	NeverVacuumed    bool               `json:"never_vacuumed"`      // True if this table has never been vacuumed, manually or by autovacuum
+	NeverAnalyzed    bool               `json:"never_analyzed"`      // True if this table has never been analyzed, manually or by autovacuum
+	LastVacuum       *sql.NullTime      `json:"last_vacuum"`         // Last time at which this table was vacuumed, manually or by autovacuum
+	LastAnalyze      *sql.NullTime      `json:"last_analyze"`        // Last time at which this table was analyzed, manually or by autovacuum
+}
+
+// WithReloptions returns settings overridden by table storage parameters, as stored in pg_class.reloptions.
+// autovacuum_enabled can only disable autovacuum, it does not enable autovacuum disabled globally.
+func (a AutovacuumSettings) WithReloptions(reloptions []string) AutovacuumSettings {
+	for _, opt := range reloptions {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "autovacuum_enabled":
+			if v, err := strconv.ParseBool(kv[1]); err == nil {
+				a.Enabled = a.Enabled && v
+			}
+		case "autovacuum_vacuum_threshold":
+			if v, err := strconv.ParseInt(kv[1], 10, 64); err == nil {
+				a.VacuumThreshold = v
+			}
+		case "autovacuum_vacuum_scale_factor":
+			if v, err := strconv.ParseFloat(kv[1], 64); err == nil {
+				a.VacuumScaleFactor = v
+			}
+		case "autovacuum_vacuum_insert_threshold":
+			if v, err := strconv.ParseInt(kv[1], 10, 64); err == nil {
+				a.VacuumInsertThreshold = v
+			}
+		case "autovacuum_vacuum_insert_scale_factor":
+			if v, err := strconv.ParseFloat(kv[1], 64); err == nil {
+				a.VacuumInsertScaleFactor = v
+			}
+		case "autovacuum_analyze_threshold":
+			if v, err := strconv.ParseInt(kv[1], 10, 64); err == nil {
+				a.AnalyzeThreshold = v
+			}
+		case "autovacuum_analyze_scale_factor":
+			if v, err := strconv.ParseFloat(kv[1], 64); err == nil {
+				a.AnalyzeScaleFactor = v
+			}
+		}
+	}
+	return a
+}
+
+func (s *Stats) fetchAutovacuumSettings(ctx context.Context) (AutovacuumSettings, error) {
+	settings, err := s.getSettingsContext(ctx,
+		"autovacuum",
+		"autovacuum_vacuum_threshold",
+		"autovacuum_vacuum_scale_factor",
+		"autovacuum_vacuum_insert_threshold",
+		"autovacuum_vacuum_insert_scale_factor",
+		"autovacuum_analyze_threshold",
+		"autovacuum_analyze_scale_factor",
+	)
+	if err != nil {
+		return AutovacuumSettings{}, err
+	}
+
+	res := AutovacuumSettings{
+		Enabled: settings["autovacuum"] == "on",
+	}
+	res.VacuumThreshold, _ = strconv.ParseInt(settings["autovacuum_vacuum_threshold"], 10, 64)
+	res.VacuumScaleFactor, _ = strconv.ParseFloat(settings["autovacuum_vacuum_scale_factor"], 64)
+	// insert-triggered vacuum is supported since PostgreSQL 13
+	res.VacuumInsertThreshold = -1
+	if v, ok := settings["autovacuum_vacuum_insert_threshold"]; ok {
+		res.VacuumInsertThreshold, _ = strconv.ParseInt(v, 10, 64)
+	}
+	res.VacuumInsertScaleFactor, _ = strconv.ParseFloat(settings["autovacuum_vacuum_insert_scale_factor"], 64)
+	res.AnalyzeThreshold, _ = strconv.ParseInt(settings["autovacuum_analyze_threshold"], 10, 64)
+	res.AnalyzeScaleFactor, _ = strconv.ParseFloat(settings["autovacuum_analyze_scale_factor"], 64)
+	return res, nil
+}
+
+func (s *Stats) fetchAutovacuum(ctx context.Context) ([]AutovacuumRow, error) {
+	settings, err := s.fetchAutovacuumSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nInsSinceVacuum := "NULL"
+	if version >= 13 {
+		nInsSinceVacuum = "s.n_ins_since_vacuum"
+	}
+
+	const query = `SELECT
+	s.relid,
+	s.schemaname,
+	s.relname,
+	c.reltuples,
+	array_to_string(c.reloptions, ','),
+	s.n_dead_tup,
+	s.n_mod_since_analyze,
+	%s,
+	s.last_vacuum,
+	s.last_autovacuum,
+	s.last_analyze,
+	s.last_autoanalyze
+	FROM pg_stat_user_tables s
+	JOIN pg_class c ON c.oid = s.relid`
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(query, nInsSinceVacuum))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []AutovacuumRow{}
+	for rows.Next() {
+		var row AutovacuumRow
+		var (
+			reloptions       sql.NullString
+			nDeadTup         sql.NullInt64
+			nModSinceAnalyze sql.NullInt64
+			nInsSinceVacuum  sql.NullInt64
+			lastAutovacuum   sql.NullTime
+			lastAutoanalyze  sql.NullTime
+		)
+
+		err := rows.Scan(
+			&row.Relid,
+			&row.Schemaname,
+			&row.Relname,
+			&row.Reltuples,
+			&reloptions,
+			&nDeadTup,
+			&nModSinceAnalyze,
+			&nInsSinceVacuum,
+			&row.LastVacuum,
+			&lastAutovacuum,
+			&row.LastAnalyze,
+			&lastAutoanalyze,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var opts []string
+		if reloptions.Valid && reloptions.String != "" {
+			opts = strings.Split(reloptions.String, ",")
+		}
+		row.Settings = settings.WithReloptions(opts)
+		row.NDeadTup = nDeadTup.Int64
+		row.NModSinceAnalyze = nModSinceAnalyze.Int64
+		row.NInsSinceVacuum = nInsSinceVacuum.Int64
+		row.LastVacuum = latestTime(row.LastVacuum, &lastAutovacuum)
+		row.LastAnalyze = latestTime(row.LastAnalyze, &lastAutoanalyze)
+		row.forecast()
+
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+func (r *AutovacuumRow) forecast() {
+	// reltuples is -1 for tables which were never vacuumed or analyzed since PostgreSQL 14.
+	reltuples := r.Reltuples
+	if reltuples < 0 {
+		reltuples = 0
+	}
+
+	r.VacuumThreshold = float64(r.Settings.VacuumThreshold) + r.Settings.VacuumScaleFactor*reltuples
+	r.AnalyzeThreshold = float64(r.Settings.AnalyzeThreshold) + r.Settings.AnalyzeScaleFactor*reltuples
+	r.VacuumPercent = percentOf(float64(r.NDeadTup), r.VacuumThreshold)
+	r.AnalyzePercent = percentOf(float64(r.NModSinceAnalyze), r.AnalyzeThreshold)
+	r.InsertThreshold = -1
+	if r.Settings.VacuumInsertThreshold >= 0 {
+		r.InsertThreshold = float64(r.Settings.VacuumInsertThreshold) + r.Settings.VacuumInsertScaleFactor*reltuples
+		r.InsertPercent = percentOf(float64(r.NInsSinceVacuum), r.InsertThreshold)
+	}
+
+	// autovacuum never processes tables with autovacuum disabled, except to prevent wraparound
+	r.VacuumOverdue = r.Settings.Enabled &&
+		(float64(r.NDeadTup) > r.VacuumThreshold || (r.InsertThreshold >= 0 && float64(r.NInsSinceVacuum) > r.InsertThreshold))
+	r.AnalyzeOverdue = r.Settings.Enabled && float64(r.NModSinceAnalyze) > r.AnalyzeThreshold
+	r.NeverVacuumed = r.LastVacuum == nil || !r.LastVacuum.Valid
+	r.NeverAnalyzed = r.LastAnalyze == nil || !r.LastAnalyze.Valid
+}
+
+func latestTime(a, b *sql.NullTime) *sql.NullTime {
+	switch {
+	case a == nil || !a.Valid:
+		return b
+	case b == nil || !b.Valid:
+		return a
+	case b.Time.After(a.Time):
+		return b
+	default:
+		return a
+	}
+}
+
+func percentOf(value, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return 100 * value / total
+}
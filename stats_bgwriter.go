@@ -4,6 +4,8 @@ import "database/sql"
 
 // BgWriter returns rows from a `pg_stat_bgwriter` view.
 // One row only, showing statistics about the background writer process's activity. See pg_stat_bgwriter for details.
+// Since PostgreSQL 17 checkpoint statistics are read from `pg_stat_checkpointer` view
+// and buffers written by backends are summed up from `pg_stat_io` view.
 //
 // See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-BGWRITER-VIEW
 func (s *Stats) BgWriter() (BgWriterView, error) {
@@ -14,18 +16,91 @@ func (s *Stats) BgWriter() (BgWriterView, error) {
 type BgWriterView struct {
 	CheckpointsTimed    *sql.NullInt64   `json:"checkpoints_timed"`     // Number of scheduled checkpoints that have been performed
 	CheckpointsReq      *sql.NullInt64   `json:"checkpoints_req"`       // Number of requested checkpoints that have been performed
+	CheckpointsDone     *sql.NullInt64   `json:"checkpoints_done"`      // Number of checkpoints that have been performed, skipped ones excluded. Supported since PostgreSQL 18.
 	CheckpointWriteTime *sql.NullFloat64 `json:"checkpoint_write_time"` // Total amount of time that has been spent in the portion of checkpoint processing
 	CheckpointSyncTime  *sql.NullFloat64 `json:"checkpoint_sync_time"`  // Total amount of time that has been spent in the portion of checkpoint processing
 	BuffersCheckpoint   *sql.NullInt64   `json:"buffers_checkpoint"`    // Number of buffers written during checkpoints
 	BuffersClean        *sql.NullInt64   `json:"buffers_clean"`         // Number of buffers written by the background writer
 	MaxWrittenClean     *sql.NullInt64   `json:"maxwritten_clean"`      // Number of times the background writer stopped a cleaning scan because it had written too many buffers
-	BuffersBackend      *sql.NullInt64   `json:"buffers_backend"`       // Number of buffers written directly by a backend
-	BuffersBackendFsync *sql.NullInt64   `json:"buffers_backend_fsync"` // Number of times a backend had to execute its own fsync call
+	BuffersBackend      *sql.NullInt64   `json:"buffers_backend"`       // Number of buffers written directly by a backend. Since PostgreSQL 17 it is the sum of relation writes of other than checkpointer and background writer processes in pg_stat_io.
+	BuffersBackendFsync *sql.NullInt64   `json:"buffers_backend_fsync"` // Number of times a backend had to execute its own fsync call. Since PostgreSQL 17 it is read from pg_stat_io the same way.
 	BuffersAlloc        *sql.NullInt64   `json:"buffers_alloc"`         // Number of buffers allocated
-	StatsReset          *sql.NullTime    `json:"stats_reset"`           // Time at which these statistics were last reset
+	StatsReset          *sql.NullTime    `json:"stats_reset"`           // Time at which these statistics were last reset. Since PostgreSQL 17 the latest reset of pg_stat_bgwriter, pg_stat_checkpointer and pg_stat_io.
 }
 
 func (s *Stats) fetchBgWriter() (BgWriterView, error) {
+	version, err := s.getVersion()
+	switch {
+	case err != nil:
+		return BgWriterView{}, err
+	case version >= 18:
+		return s.fetchBgWriter17(bgWriterQuery18)
+	case version >= 17:
+		return s.fetchBgWriter17(bgWriterQuery17)
+	default:
+		return s.fetchBgWriter96()
+	}
+}
+
+// Buffers written by backends are taken from pg_stat_io, so its resets are also reported in stats_reset.
+const bgWriterQuery18 = `SELECT
+	c.num_timed,
+	c.num_requested,
+	c.num_done,
+	c.write_time,
+	c.sync_time,
+	c.buffers_written,
+	b.buffers_clean,
+	b.maxwritten_clean,
+	io.writes,
+	io.fsyncs,
+	b.buffers_alloc,
+	GREATEST(b.stats_reset, c.stats_reset, io.stats_reset)
+	FROM pg_stat_bgwriter b, pg_stat_checkpointer c,
+	(SELECT sum(writes)::bigint AS writes, sum(fsyncs)::bigint AS fsyncs, max(stats_reset) AS stats_reset
+		FROM pg_stat_io
+		WHERE object = 'relation' AND backend_type NOT IN ('checkpointer', 'background writer')) io`
+
+const bgWriterQuery17 = `SELECT
+	c.num_timed,
+	c.num_requested,
+	NULL,
+	c.write_time,
+	c.sync_time,
+	c.buffers_written,
+	b.buffers_clean,
+	b.maxwritten_clean,
+	io.writes,
+	io.fsyncs,
+	b.buffers_alloc,
+	GREATEST(b.stats_reset, c.stats_reset, io.stats_reset)
+	FROM pg_stat_bgwriter b, pg_stat_checkpointer c,
+	(SELECT sum(writes)::bigint AS writes, sum(fsyncs)::bigint AS fsyncs, max(stats_reset) AS stats_reset
+		FROM pg_stat_io
+		WHERE object = 'relation' AND backend_type NOT IN ('checkpointer', 'background writer')) io`
+
+func (s *Stats) fetchBgWriter17(query string) (BgWriterView, error) {
+	row := s.db.QueryRow(query)
+	var res BgWriterView
+
+	err := row.Scan(
+		&res.CheckpointsTimed,
+		&res.CheckpointsReq,
+		&res.CheckpointsDone,
+		&res.CheckpointWriteTime,
+		&res.CheckpointSyncTime,
+		&res.BuffersCheckpoint,
+		&res.BuffersClean,
+		&res.MaxWrittenClean,
+		&res.BuffersBackend,
+		&res.BuffersBackendFsync,
+		&res.BuffersAlloc,
+		&res.StatsReset,
+	)
+	return res, err
+}
+
+func (s *Stats) fetchBgWriter96() (BgWriterView, error) {
 	const query = `SELECT
 	checkpoints_timed,
 	checkpoints_req,
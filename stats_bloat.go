@@ -0,0 +1,315 @@
+package pgstats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoPgstattuple is returned when precise bloat is requested but pgstattuple extension is not installed.
+var ErrNoPgstattuple = errors.New("Extension pgstattuple is not installed")
+
+// TableBloat returns estimated bloat of every user table.
+// The estimation is based on pg_stats, pg_class and the page size and requires tables to be analyzed.
+// When precise is true `pgstattuple_approx` function of the pgstattuple extension is used instead (PostgreSQL 9.5+),
+// ErrNoPgstattuple is returned if the extension is not installed.
+//
+// See: https://www.postgresql.org/docs/current/pgstattuple.html
+func (s *Stats) TableBloat(ctx context.Context, precise bool) ([]TableBloatRow, error) {
+	return s.fetchTableBloat(ctx, precise)
+}
+
+// IndexBloat returns estimated bloat of every user btree index.
+// The estimation is based on pg_stats, pg_class and the page size and requires tables to be analyzed.
+func (s *Stats) IndexBloat(ctx context.Context) ([]IndexBloatRow, error) {
+	return s.fetchIndexBloat(ctx)
+}
+
+// TableBloatRow represents bloat of a table. Relid matches TablesRow.Relid.
+type TableBloatRow struct {
+	Relid         int64   `json:"relid"`          // OID of a table
+	Schemaname    string  `json:"schemaname"`     // Name of the schema that this table is in
+	Relname       string  `json:"relname"`        // Name of this table
+	RealSize      int64   `json:"real_size"`      // Size of the table in bytes, including TOAST unless Precise is true
+	BloatSize     int64   `json:"bloat_size"`     // Amount of space in bytes which is not used by live rows, beyond the fillfactor
+	BloatPercent  float64 `json:"bloat_percent"`  // Percent of RealSize taken by bloat
+	Fillfactor    int64   `json:"fillfactor"`     // Fillfactor of the table
+	NotApplicable bool    `json:"not_applicable"` // True if the estimation is unreliable, e.g. the table has no statistics or has columns of type name
+	Precise       bool    `json:"precise"`        // True if bloat is computed by pgstattuple_approx
+}
+
+// IndexBloatRow represents bloat of a btree index. Relid and Indexrelid match IndexesRow.
+type IndexBloatRow struct {
+	Relid         int64   `json:"relid"`          // OID of the table for this index
+	Indexrelid    int64   `json:"indexrelid"`     // OID of this index
+	Schemaname    string  `json:"schemaname"`     // Name of the schema this index is in
+	Relname       string  `json:"relname"`        // Name of the table for this index
+	Indexrelname  string  `json:"indexrelname"`   // Name of this index
+	RealSize      int64   `json:"real_size"`      // Size of the index in bytes
+	BloatSize     int64   `json:"bloat_size"`     // Amount of space in bytes which is not used by index tuples, beyond the fillfactor
+	BloatPercent  float64 `json:"bloat_percent"`  // Percent of RealSize taken by bloat
+	Fillfactor    int64   `json:"fillfactor"`     // Fillfactor of the index
+	NotApplicable bool    `json:"not_applicable"` // True if the estimation is unreliable, e.g. the index has columns of type name
+}
+
+func (s *Stats) fetchTableBloat(ctx context.Context, precise bool) ([]TableBloatRow, error) {
+	query := tableBloatQuery
+	if precise {
+		version, err := s.getVersionContext(ctx)
+		switch {
+		case err != nil:
+			return nil, err
+		case version < 9.5:
+			return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+		}
+
+		const extQuery = `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pgstattuple')`
+
+		var installed bool
+		if err := s.db.QueryRowContext(ctx, extQuery).Scan(&installed); err != nil {
+			return nil, err
+		}
+		if !installed {
+			return nil, ErrNoPgstattuple
+		}
+		query = tableBloatPreciseQuery
+	}
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []TableBloatRow{}
+	for rows.Next() {
+		var row TableBloatRow
+
+		err := rows.Scan(
+			&row.Relid,
+			&row.Schemaname,
+			&row.Relname,
+			&row.RealSize,
+			&row.BloatSize,
+			&row.BloatPercent,
+			&row.Fillfactor,
+			&row.NotApplicable,
+		)
+		if err != nil {
+			return nil, err
+		}
+		row.Precise = precise
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+func (s *Stats) fetchIndexBloat(ctx context.Context) ([]IndexBloatRow, error) {
+	rows, err := s.db.QueryContext(ctx, indexBloatQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []IndexBloatRow{}
+	for rows.Next() {
+		var row IndexBloatRow
+
+		err := rows.Scan(
+			&row.Relid,
+			&row.Indexrelid,
+			&row.Schemaname,
+			&row.Relname,
+			&row.Indexrelname,
+			&row.RealSize,
+			&row.BloatSize,
+			&row.BloatPercent,
+			&row.Fillfactor,
+			&row.NotApplicable,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+// tableBloatQuery estimates the size of a table from average row width and row count,
+// see https://github.com/ioguix/pgsql-bloat-estimation for details.
+const tableBloatQuery = `SELECT
+	tblid,
+	schemaname,
+	tblname,
+	(bs * tblpages)::bigint,
+	CASE WHEN tblpages > est_tblpages_ff THEN ((tblpages - est_tblpages_ff) * bs)::bigint ELSE 0 END,
+	CASE WHEN tblpages > 0 AND tblpages > est_tblpages_ff THEN (100 * (tblpages - est_tblpages_ff) / tblpages)::float8 ELSE 0 END,
+	fillfactor,
+	is_na
+	FROM (
+		SELECT
+		ceil(reltuples / ((bs - page_hdr) * fillfactor / (tpl_size * 100))) + ceil(toasttuples / 4) AS est_tblpages_ff,
+		tblpages, fillfactor, bs, tblid, schemaname, tblname, is_na
+		FROM (
+			SELECT
+			(4 + tpl_hdr_size + tpl_data_size + (2 * ma)
+				- CASE WHEN tpl_hdr_size % ma = 0 THEN ma ELSE tpl_hdr_size % ma END
+				- CASE WHEN ceil(tpl_data_size)::int % ma = 0 THEN ma ELSE ceil(tpl_data_size)::int % ma END
+			) AS tpl_size,
+			(heappages + toastpages) AS tblpages,
+			reltuples, toasttuples, bs, page_hdr, tblid, schemaname, tblname, fillfactor, is_na
+			FROM (
+				SELECT
+				tbl.oid AS tblid,
+				ns.nspname AS schemaname,
+				tbl.relname AS tblname,
+				tbl.reltuples,
+				tbl.relpages AS heappages,
+				coalesce(toast.relpages, 0) AS toastpages,
+				coalesce(toast.reltuples, 0) AS toasttuples,
+				coalesce(substring(array_to_string(tbl.reloptions, ' ') FROM 'fillfactor=([0-9]+)')::smallint, 100) AS fillfactor,
+				current_setting('block_size')::numeric AS bs,
+				CASE WHEN version() ~ 'mingw32' OR version() ~ '64-bit|x86_64|ppc64|ia64|amd64' THEN 8 ELSE 4 END AS ma,
+				24 AS page_hdr,
+				23 + CASE WHEN max(coalesce(s.null_frac, 0)) > 0 THEN (7 + count(s.attname)) / 8 ELSE 0::int END
+					+ CASE WHEN bool_or(att.attname = 'oid' AND att.attnum < 0) THEN 4 ELSE 0 END AS tpl_hdr_size,
+				sum((1 - coalesce(s.null_frac, 0)) * coalesce(s.avg_width, 0)) AS tpl_data_size,
+				bool_or(att.atttypid = 'pg_catalog.name'::regtype)
+					OR sum(CASE WHEN att.attnum > 0 THEN 1 ELSE 0 END) <> count(s.attname)
+					OR tbl.reltuples < 0 AS is_na
+				FROM pg_attribute att
+				JOIN pg_class tbl ON att.attrelid = tbl.oid
+				JOIN pg_namespace ns ON ns.oid = tbl.relnamespace
+				LEFT JOIN pg_stats s ON s.schemaname = ns.nspname
+					AND s.tablename = tbl.relname
+					AND s.inherited = false
+					AND s.attname = att.attname
+				LEFT JOIN pg_class toast ON tbl.reltoastrelid = toast.oid
+				WHERE NOT att.attisdropped
+				AND tbl.relkind IN ('r', 'm')
+				AND ns.nspname NOT IN ('pg_catalog', 'information_schema')
+				AND ns.nspname !~ '^pg_toast'
+				GROUP BY 1, 2, 3, 4, 5, 6, 7, 8, 9, 10
+			) s
+		) s2
+	) s3`
+
+// tableBloatPreciseQuery excludes free space reserved by fillfactor to match tableBloatQuery.
+// Temporary tables of other sessions and tables of non-heap access methods (relam is 0 before PostgreSQL 12)
+// are skipped because pgstattuple_approx cannot read them.
+const tableBloatPreciseQuery = `SELECT
+	oid,
+	nspname,
+	relname,
+	table_len,
+	bloat_size,
+	CASE WHEN table_len > 0 THEN (100 * bloat_size / table_len)::float8 ELSE 0 END,
+	fillfactor,
+	false
+	FROM (
+		SELECT
+		oid, nspname, relname, table_len, fillfactor,
+		greatest(approx_free_space + dead_tuple_len - table_len * (100 - fillfactor) / 100, 0)::bigint AS bloat_size
+		FROM (
+			SELECT
+			c.oid,
+			n.nspname,
+			c.relname,
+			a.table_len,
+			a.approx_free_space,
+			a.dead_tuple_len,
+			coalesce(substring(array_to_string(c.reloptions, ' ') FROM 'fillfactor=([0-9]+)')::smallint, 100) AS fillfactor
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace,
+			LATERAL pgstattuple_approx(c.oid) a
+			WHERE c.relkind IN ('r', 'm')
+			AND c.relpersistence <> 't'
+			AND (c.relam = 0 OR c.relam = (SELECT oid FROM pg_am WHERE amname = 'heap'))
+			AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+			AND n.nspname !~ '^pg_toast'
+		) a
+	) b`
+
+// indexBloatQuery estimates the size of a btree index from average key width and row count,
+// see https://github.com/ioguix/pgsql-bloat-estimation for details.
+const indexBloatQuery = `SELECT
+	tbloid,
+	idxoid,
+	nspname,
+	tblname,
+	idxname,
+	(bs * relpages)::bigint,
+	CASE WHEN relpages > est_pages_ff THEN (bs * (relpages - est_pages_ff))::bigint ELSE 0 END,
+	CASE WHEN relpages > est_pages_ff THEN (100 * (relpages - est_pages_ff) / relpages)::float8 ELSE 0 END,
+	fillfactor,
+	is_na
+	FROM (
+		SELECT
+		coalesce(1 + ceil(reltuples / floor((bs - pageopqdata - pagehdr) * fillfactor / (100 * (4 + nulldatahdrwidth)::float))), 0) AS est_pages_ff,
+		bs, nspname, tblname, idxname, tbloid, idxoid, relpages, fillfactor, is_na
+		FROM (
+			SELECT
+			bs, nspname, tblname, idxname, tbloid, idxoid, reltuples, relpages, fillfactor, pagehdr, pageopqdata, is_na,
+			(index_tuple_hdr_bm
+				+ maxalign - CASE WHEN index_tuple_hdr_bm % maxalign = 0 THEN maxalign ELSE index_tuple_hdr_bm % maxalign END
+				+ nulldatawidth + maxalign - CASE
+					WHEN nulldatawidth = 0 THEN 0
+					WHEN nulldatawidth::integer % maxalign = 0 THEN maxalign
+					ELSE nulldatawidth::integer % maxalign
+				END
+			)::numeric AS nulldatahdrwidth
+			FROM (
+				SELECT
+				n.nspname, i.tblname, i.idxname, i.tbloid, i.idxoid, i.reltuples, i.relpages, i.fillfactor,
+				current_setting('block_size')::numeric AS bs,
+				CASE WHEN version() ~ 'mingw32' OR version() ~ '64-bit|x86_64|ppc64|ia64|amd64' THEN 8 ELSE 4 END AS maxalign,
+				24 AS pagehdr,
+				16 AS pageopqdata,
+				CASE WHEN max(coalesce(s.null_frac, 0)) = 0 THEN 8 ELSE 8 + ((32 + 8 - 1) / 8) END AS index_tuple_hdr_bm,
+				sum((1 - coalesce(s.null_frac, 0)) * coalesce(s.avg_width, 1024)) AS nulldatawidth,
+				max(CASE WHEN i.atttypid = 'pg_catalog.name'::regtype THEN 1 ELSE 0 END) > 0 AS is_na
+				FROM (
+					SELECT
+					ct.relname AS tblname, ct.relnamespace, ic.idxname, ic.reltuples, ic.relpages, ic.tbloid, ic.idxoid, ic.fillfactor,
+					coalesce(a1.attname, a2.attname) AS attname,
+					coalesce(a1.atttypid, a2.atttypid) AS atttypid,
+					CASE WHEN a1.attnum IS NULL THEN ic.idxname ELSE ct.relname END AS attrelname
+					FROM (
+						SELECT
+						idxname, reltuples, relpages, tbloid, idxoid, fillfactor, indkey,
+						generate_series(1, indnatts) AS attpos
+						FROM (
+							SELECT
+							ci.relname AS idxname,
+							ci.reltuples,
+							ci.relpages,
+							i.indrelid AS tbloid,
+							i.indexrelid AS idxoid,
+							coalesce(substring(array_to_string(ci.reloptions, ' ') FROM 'fillfactor=([0-9]+)')::smallint, 90) AS fillfactor,
+							i.indnatts,
+							string_to_array(textin(int2vectorout(i.indkey)), ' ')::int[] AS indkey
+							FROM pg_index i
+							JOIN pg_class ci ON ci.oid = i.indexrelid
+							WHERE ci.relam = (SELECT oid FROM pg_am WHERE amname = 'btree')
+							AND ci.relpages > 0
+						) idx_data
+					) ic
+					JOIN pg_class ct ON ct.oid = ic.tbloid
+					LEFT JOIN pg_attribute a1 ON ic.indkey[ic.attpos] <> 0
+						AND a1.attrelid = ic.tbloid
+						AND a1.attnum = ic.indkey[ic.attpos]
+					LEFT JOIN pg_attribute a2 ON ic.indkey[ic.attpos] = 0
+						AND a2.attrelid = ic.idxoid
+						AND a2.attnum = ic.attpos
+				) i
+				JOIN pg_namespace n ON n.oid = i.relnamespace
+				JOIN pg_stats s ON s.schemaname = n.nspname
+					AND s.tablename = i.attrelname
+					AND s.inherited = false
+					AND s.attname = i.attname
+				WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+				AND n.nspname !~ '^pg_toast'
+				GROUP BY 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12
+			) rows_data_stats
+		) rows_hdr_pdg_stats
+	) relation_stats`
@@ -0,0 +1,156 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+// Connections returns client connections grouped by database, user, application, client address and state,
+// compared against max_connections and per-database and per-role connection limits.
+//
+// See: https://www.postgresql.org/docs/current/runtime-config-connection.html
+func (s *Stats) Connections(ctx context.Context) (ConnectionsView, error) {
+	return s.fetchConnections(ctx)
+}
+
+// ConnectionsView represents connection saturation of the server.
+type ConnectionsView struct {
+	MaxConnections               int64                `json:"max_connections"`                // Value of max_connections setting
+	SuperuserReservedConnections int64                `json:"superuser_reserved_connections"` // Value of superuser_reserved_connections setting
+	ReservedConnections          int64                `json:"reserved_connections"`           // Value of reserved_connections setting. Supported since PostgreSQL 16.
+	Total                        int64                `json:"total"`                          // Number of client connections
+	Headroom                     int64                `json:"headroom"`                       // Number of connections available to roles without special privileges
+	Groups                       []ConnectionsGroup   `json:"groups"`                         // Client connections grouped by datname, usename, application_name, client_addr and state
+	Databases                    []ConnectionLimitRow `json:"databases"`                      // Connections of every database compared to datconnlimit
+	Roles                        []ConnectionLimitRow `json:"roles"`                          // Connections of every login role compared to rolconnlimit
+}
+
+// ConnectionsGroup represents a number of client connections with the same attributes.
+type ConnectionsGroup struct {
+	Datname         *sql.NullString `json:"datname"`          // Name of the database the backends are connected to
+	Usename         *sql.NullString `json:"usename"`          // Name of the user logged into the backends
+	ApplicationName *sql.NullString `json:"application_name"` // Name of the application that is connected to the backends
+	ClientAddr      *sql.NullString `json:"client_addr"`      // IP address of the client connected to the backends, null for Unix sockets
+	State           *sql.NullString `json:"state"`            // Current overall state of the backends
+	Count           int64           `json:"count"`            // Number of backends
+}
+
+// ConnectionLimitRow represents connections of a database or a role compared to its connection limit.
+type ConnectionLimitRow struct {
+	Name     string         `json:"name"`     // Name of a database or a role
+	Limit    int64          `json:"limit"`    // datconnlimit or rolconnlimit, -1 means no limit
+	Count    int64          `json:"count"`    // Number of client connections
+	Headroom *sql.NullInt64 `json:"headroom"` // Number of connections left before the limit, null if there is no limit
+}
+
+func (s *Stats) fetchConnections(ctx context.Context) (ConnectionsView, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return ConnectionsView{}, err
+	}
+
+	settings, err := s.getSettingsContext(ctx, "max_connections", "superuser_reserved_connections", "reserved_connections")
+	if err != nil {
+		return ConnectionsView{}, err
+	}
+
+	var res ConnectionsView
+	res.MaxConnections, _ = strconv.ParseInt(settings["max_connections"], 10, 64)
+	res.SuperuserReservedConnections, _ = strconv.ParseInt(settings["superuser_reserved_connections"], 10, 64)
+	res.ReservedConnections, _ = strconv.ParseInt(settings["reserved_connections"], 10, 64)
+
+	clients := "true"
+	if version >= 10 {
+		clients = "a.backend_type = 'client backend'"
+	}
+
+	res.Groups, err = s.fetchConnectionsGroups(ctx, clients)
+	if err != nil {
+		return res, err
+	}
+	for _, group := range res.Groups {
+		res.Total += group.Count
+	}
+	res.Headroom = res.MaxConnections - res.SuperuserReservedConnections - res.ReservedConnections - res.Total
+
+	const databasesQuery = `SELECT d.datname, d.datconnlimit, count(a.pid)
+	FROM pg_database d
+	LEFT JOIN pg_stat_activity a ON a.datid = d.oid AND `
+	res.Databases, err = s.fetchConnectionLimits(ctx, databasesQuery+clients+` WHERE d.datallowconn GROUP BY 1, 2 ORDER BY 1`)
+	if err != nil {
+		return res, err
+	}
+
+	const rolesQuery = `SELECT r.rolname, r.rolconnlimit, count(a.pid)
+	FROM pg_roles r
+	LEFT JOIN pg_stat_activity a ON a.usesysid = r.oid AND `
+	res.Roles, err = s.fetchConnectionLimits(ctx, rolesQuery+clients+` WHERE r.rolcanlogin GROUP BY 1, 2 ORDER BY 1`)
+	return res, err
+}
+
+func (s *Stats) fetchConnectionsGroups(ctx context.Context, clients string) ([]ConnectionsGroup, error) {
+	const query = `SELECT
+	a.datname,
+	a.usename,
+	a.application_name,
+	host(a.client_addr),
+	a.state,
+	count(*)
+	FROM pg_stat_activity a
+	WHERE `
+
+	rows, err := s.db.QueryContext(ctx, query+clients+` GROUP BY 1, 2, 3, 4, 5 ORDER BY 6 DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ConnectionsGroup{}
+	for rows.Next() {
+		var row ConnectionsGroup
+
+		err := rows.Scan(
+			&row.Datname,
+			&row.Usename,
+			&row.ApplicationName,
+			&row.ClientAddr,
+			&row.State,
+			&row.Count,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+func (s *Stats) fetchConnectionLimits(ctx context.Context, query string) ([]ConnectionLimitRow, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ConnectionLimitRow{}
+	for rows.Next() {
+		var row ConnectionLimitRow
+
+		err := rows.Scan(
+			&row.Name,
+			&row.Limit,
+			&row.Count,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Headroom = &sql.NullInt64{}
+		if row.Limit >= 0 {
+			row.Headroom.Int64, row.Headroom.Valid = row.Limit-row.Count, true
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
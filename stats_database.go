@@ -1,9 +1,13 @@
 package pgstats
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+)
 
 // Database returns rows from a `pg_stat_database` view.
 // One row per database, showing database-wide statistics.
+// Since PostgreSQL 12 the view also has a row with zero datid and empty datname for shared objects.
 //
 // See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-DATABASE-VIEW
 func (s *Stats) Database() ([]DatabaseRow, error) {
@@ -13,7 +17,7 @@ func (s *Stats) Database() ([]DatabaseRow, error) {
 // DatabaseRow represents schema of pg_stat_database view
 type DatabaseRow struct {
 	Datid        int64            `json:"datid"`          // OID of a database
-	Datname      string           `json:"datname"`        // Name of this database
+	Datname      string           `json:"datname"`        // Name of this database, empty for shared objects
 	NumBackends  int64            `json:"numbackends"`    // Number of backends currently connected to this database.
 	XactCommit   *sql.NullInt64   `json:"xact_commit"`    // Number of transactions in this database that have been committed
 	XactRollback *sql.NullInt64   `json:"xact_rollback"`  //	Number of transactions in this database that have been rolled back
@@ -31,12 +35,40 @@ type DatabaseRow struct {
 	BlkReadTime  *sql.NullFloat64 `json:"blk_read_time"`  // Time spent reading data file blocks by backends in this database, in milliseconds
 	BlkWriteTime *sql.NullFloat64 `json:"blk_write_time"` // Time spent writing data file blocks by backends in this database, in milliseconds
 	StatsReset   *sql.NullTime    `json:"stats_reset"`    // Time at which these statistics were last reset
+	Size         *sql.NullInt64   `json:"size"`           // Disk space used by this database in bytes, null if sizes are disabled or the database is not accessible
+
+	ChecksumFailures        *sql.NullInt64   `json:"checksum_failures"`          // Number of data page checksum failures detected in this database. Supported since PostgreSQL 12.
+	ChecksumLastFailure     *sql.NullTime    `json:"checksum_last_failure"`      // Time at which the last data page checksum failure was detected in this database. Supported since PostgreSQL 12.
+	SessionTime             *sql.NullFloat64 `json:"session_time"`               // Time spent by database sessions in this database, in milliseconds. Supported since PostgreSQL 14.
+	ActiveTime              *sql.NullFloat64 `json:"active_time"`                // Time spent executing SQL statements in this database, in milliseconds. Supported since PostgreSQL 14.
+	IdleInTransactionTime   *sql.NullFloat64 `json:"idle_in_transaction_time"`   // Time spent idling while in a transaction in this database, in milliseconds. Supported since PostgreSQL 14.
+	Sessions                *sql.NullInt64   `json:"sessions"`                   // Total number of sessions established to this database. Supported since PostgreSQL 14.
+	SessionsAbandoned       *sql.NullInt64   `json:"sessions_abandoned"`         // Number of database sessions that were terminated because connection to the client was lost. Supported since PostgreSQL 14.
+	SessionsFatal           *sql.NullInt64   `json:"sessions_fatal"`             // Number of database sessions that were terminated by fatal errors. Supported since PostgreSQL 14.
+	SessionsKilled          *sql.NullInt64   `json:"sessions_killed"`            // Number of database sessions that were terminated by operator intervention. Supported since PostgreSQL 14.
+	ParallelWorkersToLaunch *sql.NullInt64   `json:"parallel_workers_to_launch"` // Number of parallel workers planned to be launched by queries on this database. Supported since PostgreSQL 18.
+	ParallelWorkersLaunched *sql.NullInt64   `json:"parallel_workers_launched"`  // Number of parallel workers launched by queries on this database. Supported since PostgreSQL 18.
 }
 
 func (s *Stats) fetchDatabases() ([]DatabaseRow, error) {
-	const query = `SELECT
+	version, err := s.getVersion()
+	switch {
+	case err != nil:
+		return nil, err
+	case version >= 18:
+		return s.fetchDatabasesQuery(databaseQuery18)
+	case version >= 14:
+		return s.fetchDatabasesQuery(databaseQuery14)
+	case version >= 12:
+		return s.fetchDatabasesQuery(databaseQuery12)
+	default:
+		return s.fetchDatabasesQuery(databaseQuery96)
+	}
+}
+
+const databaseQueryFmt = `SELECT
 	datid,
-	datname,
+	COALESCE(datname, ''),
 	numbackends,
 	xact_commit,
 	xact_rollback,
@@ -53,10 +85,46 @@ func (s *Stats) fetchDatabases() ([]DatabaseRow, error) {
 	deadlocks,
 	blk_read_time,
 	blk_write_time,
-	stats_reset
+	stats_reset,
+	CASE WHEN $1 AND has_database_privilege(datid, 'CONNECT') THEN pg_database_size(datid) END,
+	%s
 	FROM pg_stat_database`
 
-	rows, err := s.db.Query(query)
+var (
+	databaseQuery18 = fmt.Sprintf(databaseQueryFmt, `checksum_failures,
+	checksum_last_failure,
+	session_time,
+	active_time,
+	idle_in_transaction_time,
+	sessions,
+	sessions_abandoned,
+	sessions_fatal,
+	sessions_killed,
+	parallel_workers_to_launch,
+	parallel_workers_launched`)
+
+	databaseQuery14 = fmt.Sprintf(databaseQueryFmt, `checksum_failures,
+	checksum_last_failure,
+	session_time,
+	active_time,
+	idle_in_transaction_time,
+	sessions,
+	sessions_abandoned,
+	sessions_fatal,
+	sessions_killed,
+	NULL,
+	NULL`)
+
+	databaseQuery12 = fmt.Sprintf(databaseQueryFmt, `checksum_failures,
+	checksum_last_failure,
+	NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL`)
+
+	databaseQuery96 = fmt.Sprintf(databaseQueryFmt, `NULL, NULL,
+	NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL`)
+)
+
+func (s *Stats) fetchDatabasesQuery(query string) ([]DatabaseRow, error) {
+	rows, err := s.db.Query(query, !s.noSizes)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +154,18 @@ func (s *Stats) fetchDatabases() ([]DatabaseRow, error) {
 			&row.BlkReadTime,
 			&row.BlkWriteTime,
 			&row.StatsReset,
+			&row.Size,
+			&row.ChecksumFailures,
+			&row.ChecksumLastFailure,
+			&row.SessionTime,
+			&row.ActiveTime,
+			&row.IdleInTransactionTime,
+			&row.Sessions,
+			&row.SessionsAbandoned,
+			&row.SessionsFatal,
+			&row.SessionsKilled,
+			&row.ParallelWorkersToLaunch,
+			&row.ParallelWorkersLaunched,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,81 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Gssapi represents content of `pg_stat_gssapi` view.
+// One row per backend, showing information about GSSAPI authentication and encryption used on this connection.
+// Requires PostgreSQL 12+.
+//
+// See: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-GSSAPI-VIEW
+func (s *Stats) Gssapi() ([]GssapiRow, error) {
+	return s.fetchGssapi(context.Background())
+}
+
+// GssapiRow represents schema of pg_stat_gssapi view.
+type GssapiRow struct {
+	Pid                  int64           `json:"pid"`                   // Process ID of a backend
+	GssAuthenticated     bool            `json:"gss_authenticated"`     // True if GSSAPI authentication was used for this connection
+	Principal            *sql.NullString `json:"principal"`             // Principal used to authenticate this connection, or NULL if GSSAPI was not used
+	Encrypted            bool            `json:"encrypted"`             // True if GSSAPI encryption is in use on this connection
+	CredentialsDelegated *sql.NullBool   `json:"credentials_delegated"` // True if GSSAPI credentials were delegated on this connection. Supported since PostgreSQL 16.
+}
+
+func (s *Stats) fetchGssapi(ctx context.Context) ([]GssapiRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version >= 16:
+		return s.fetchGssapiQuery(ctx, gssapiQuery16)
+	case version >= 12:
+		return s.fetchGssapiQuery(ctx, gssapiQuery12)
+	default:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	}
+}
+
+const gssapiQuery16 = `SELECT
+	pid,
+	gss_authenticated,
+	principal,
+	encrypted,
+	credentials_delegated
+	FROM pg_stat_gssapi`
+
+const gssapiQuery12 = `SELECT
+	pid,
+	gss_authenticated,
+	principal,
+	encrypted,
+	NULL
+	FROM pg_stat_gssapi`
+
+func (s *Stats) fetchGssapiQuery(ctx context.Context, query string) ([]GssapiRow, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []GssapiRow{}
+	for rows.Next() {
+		var row GssapiRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.GssAuthenticated,
+			&row.Principal,
+			&row.Encrypted,
+			&row.CredentialsDelegated,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
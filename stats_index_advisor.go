@@ -0,0 +1,280 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+)
+
+// IndexAdvisor returns user indexes which waste space: never scanned, duplicate, redundant and invalid ones.
+// Indexes enforcing unique, primary key or other constraints are never reported as unused or redundant.
+//
+// See: https://www.postgresql.org/docs/current/catalog-pg-index.html
+func (s *Stats) IndexAdvisor(ctx context.Context) (IndexAdvisorView, error) {
+	return s.fetchIndexAdvisor(ctx)
+}
+
+// IndexAdvisorView represents indexes which are candidates for removal or rebuild.
+type IndexAdvisorView struct {
+	Unused     []IndexAdviceRow `json:"unused"`      // Indexes which have never been scanned since statistics were last reset
+	Duplicate  []IndexAdviceRow `json:"duplicate"`   // Indexes which are exact duplicates of another index
+	Redundant  []IndexAdviceRow `json:"redundant"`   // Indexes whose key columns are a prefix of key columns of another index on the same table
+	Invalid    []IndexAdviceRow `json:"invalid"`     // Indexes which are invalid, e.g. after a failed CREATE INDEX CONCURRENTLY
+	WastedSize int64            `json:"wasted_size"` // Total size of reported indexes in bytes, an index reported in several lists is counted once
+}
+
+// IndexAdviceRow represents an index reported by IndexAdvisor.
+type IndexAdviceRow struct {
+	Relid        int64          `json:"relid"`        // OID of the table for this index
+	Indexrelid   int64          `json:"indexrelid"`   // OID of this index
+	Schemaname   string         `json:"schemaname"`   // Name of the schema this index is in
+	Relname      string         `json:"relname"`      // Name of the table for this index
+	Indexrelname string         `json:"indexrelname"` // Name of this index
+	IdxScan      *sql.NullInt64 `json:"idx_scan"`     // Number of index scans initiated on this index
+	Definition   string         `json:"definition"`   // Reconstructed CREATE INDEX command of this index
+	WastedSize   int64          `json:"wasted_size"`  // Size of this index in bytes, 0 if sizes are disabled
+	CoveredBy    string         `json:"covered_by"`   // Name of the index which duplicates or covers this index, if any
+}
+
+// indexDefinition is a row of pg_index with the details needed to compare indexes.
+type indexDefinition struct {
+	indexrelid  int64
+	isUnique    bool
+	isPrimary   bool
+	isValid     bool
+	constraint  bool
+	keys        string // key columns only, without INCLUDE ones
+	include     string
+	classes     string
+	collations  string
+	options     string
+	expressions string
+	predicate   string
+	method      string
+	size        int64
+	definition  string
+}
+
+func (s *Stats) fetchIndexAdvisor(ctx context.Context) (IndexAdvisorView, error) {
+	indexes, err := s.fetchIndexes(ctx, "pg_stat_user_indexes")
+	if err != nil {
+		return IndexAdvisorView{}, err
+	}
+	defs, err := s.fetchIndexDefinitions(ctx)
+	if err != nil {
+		return IndexAdvisorView{}, err
+	}
+	return adviseIndexes(indexes, defs), nil
+}
+
+// indexDefinitionsQuery11 splits indkey into key and INCLUDE columns, which are supported since PostgreSQL 11.
+const indexDefinitionsQuery11 = `SELECT
+	i.indexrelid,
+	i.indisunique,
+	i.indisprimary,
+	i.indisvalid,
+	EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid),
+	array_to_string((i.indkey::int2[])[0:i.indnkeyatts - 1], ' '),
+	array_to_string((i.indkey::int2[])[i.indnkeyatts:i.indnatts - 1], ' '),
+	i.indclass::text,
+	i.indcollation::text,
+	i.indoption::text,
+	coalesce(pg_get_expr(i.indexprs, i.indrelid), ''),
+	coalesce(pg_get_expr(i.indpred, i.indrelid), ''),
+	am.amname,
+	CASE WHEN $1 THEN pg_relation_size(i.indexrelid) ELSE 0 END,
+	pg_get_indexdef(i.indexrelid)
+	FROM pg_index i
+	JOIN pg_class c ON c.oid = i.indexrelid
+	JOIN pg_am am ON am.oid = c.relam`
+
+const indexDefinitionsQuery96 = `SELECT
+	i.indexrelid,
+	i.indisunique,
+	i.indisprimary,
+	i.indisvalid,
+	EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid),
+	i.indkey::text,
+	'',
+	i.indclass::text,
+	i.indcollation::text,
+	i.indoption::text,
+	coalesce(pg_get_expr(i.indexprs, i.indrelid), ''),
+	coalesce(pg_get_expr(i.indpred, i.indrelid), ''),
+	am.amname,
+	CASE WHEN $1 THEN pg_relation_size(i.indexrelid) ELSE 0 END,
+	pg_get_indexdef(i.indexrelid)
+	FROM pg_index i
+	JOIN pg_class c ON c.oid = i.indexrelid
+	JOIN pg_am am ON am.oid = c.relam`
+
+func (s *Stats) fetchIndexDefinitions(ctx context.Context) (map[int64]indexDefinition, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := indexDefinitionsQuery11
+	if version < 11 {
+		query = indexDefinitionsQuery96
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, !s.noSizes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := map[int64]indexDefinition{}
+	for rows.Next() {
+		var def indexDefinition
+
+		err := rows.Scan(
+			&def.indexrelid,
+			&def.isUnique,
+			&def.isPrimary,
+			&def.isValid,
+			&def.constraint,
+			&def.keys,
+			&def.include,
+			&def.classes,
+			&def.collations,
+			&def.options,
+			&def.expressions,
+			&def.predicate,
+			&def.method,
+			&def.size,
+			&def.definition,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data[def.indexrelid] = def
+	}
+	return data, rows.Err()
+}
+
+func adviseIndexes(indexes []IndexesRow, defs map[int64]indexDefinition) IndexAdvisorView {
+	res := IndexAdvisorView{
+		Unused:    []IndexAdviceRow{},
+		Duplicate: []IndexAdviceRow{},
+		Redundant: []IndexAdviceRow{},
+		Invalid:   []IndexAdviceRow{},
+	}
+
+	// sort by name to report the same index of a duplicate group every time
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i].Relid != indexes[j].Relid {
+			return indexes[i].Relid < indexes[j].Relid
+		}
+		return indexes[i].Indexrelname < indexes[j].Indexrelname
+	})
+
+	newAdvice := func(index IndexesRow, def indexDefinition, coveredBy string) IndexAdviceRow {
+		return IndexAdviceRow{
+			Relid:        index.Relid,
+			Indexrelid:   index.Indexrelid,
+			Schemaname:   index.Schemaname,
+			Relname:      index.Relname,
+			Indexrelname: index.Indexrelname,
+			IdxScan:      index.IdxScan,
+			Definition:   def.definition,
+			WastedSize:   def.size,
+			CoveredBy:    coveredBy,
+		}
+	}
+	enforces := func(def indexDefinition) bool {
+		return def.isUnique || def.isPrimary || def.constraint
+	}
+
+	for i, index := range indexes {
+		def, ok := defs[index.Indexrelid]
+		if !ok {
+			continue
+		}
+
+		if !def.isValid {
+			res.Invalid = append(res.Invalid, newAdvice(index, def, ""))
+			continue
+		}
+		if !enforces(def) && (index.IdxScan == nil || !index.IdxScan.Valid || index.IdxScan.Int64 == 0) {
+			res.Unused = append(res.Unused, newAdvice(index, def, ""))
+		}
+
+		for j, other := range indexes {
+			otherDef, ok := defs[other.Indexrelid]
+			if i == j || !ok || !otherDef.isValid || other.Relid != index.Relid {
+				continue
+			}
+
+			if def.sameAs(otherDef) {
+				// keep the index enforcing a constraint, otherwise the first one by name
+				if enforces(def) && !enforces(otherDef) {
+					continue
+				}
+				if enforces(def) == enforces(otherDef) && i < j {
+					continue
+				}
+				res.Duplicate = append(res.Duplicate, newAdvice(index, def, other.Indexrelname))
+				break
+			}
+			if !enforces(def) && def.prefixOf(otherDef) {
+				res.Redundant = append(res.Redundant, newAdvice(index, def, other.Indexrelname))
+				break
+			}
+		}
+	}
+
+	// an unused index can be also a duplicate or redundant one, its size is wasted once
+	counted := map[int64]bool{}
+	for _, list := range [][]IndexAdviceRow{res.Unused, res.Duplicate, res.Redundant, res.Invalid} {
+		for _, row := range list {
+			if !counted[row.Indexrelid] {
+				counted[row.Indexrelid] = true
+				res.WastedSize += row.WastedSize
+			}
+		}
+	}
+	return res
+}
+
+// sameAs reports whether d and o have the same method, key and INCLUDE columns, operator classes,
+// collations, sort options, expressions and predicate.
+func (d indexDefinition) sameAs(o indexDefinition) bool {
+	return d.method == o.method &&
+		d.keys == o.keys &&
+		d.include == o.include &&
+		d.classes == o.classes &&
+		d.collations == o.collations &&
+		d.options == o.options &&
+		d.expressions == o.expressions &&
+		d.predicate == o.predicate
+}
+
+// prefixOf reports whether key columns of the btree index d are a prefix of key columns of o,
+// with the same operator classes, collations and sort options (DESC, NULLS FIRST).
+// d must have no INCLUDE columns, which could be needed by index-only scans, and must not be the same as o.
+func (d indexDefinition) prefixOf(o indexDefinition) bool {
+	if d.method != "btree" || o.method != "btree" ||
+		d.expressions != "" || o.expressions != "" ||
+		d.predicate != o.predicate ||
+		d.include != "" || d.sameAs(o) {
+		return false
+	}
+	return isPrefix(strings.Fields(d.keys), strings.Fields(o.keys)) &&
+		isPrefix(strings.Fields(d.classes), strings.Fields(o.classes)) &&
+		isPrefix(strings.Fields(d.collations), strings.Fields(o.collations)) &&
+		isPrefix(strings.Fields(d.options), strings.Fields(o.options))
+}
+
+func isPrefix(a, b []string) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,26 +1,30 @@
 package pgstats
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
 
 // AllIndexes represents content of `pg_stat_all_indexes` view.
 //
 // See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-ALL-INDEXES-VIEW
 func (s *Stats) AllIndexes() ([]IndexesRow, error) {
-	return s.fetchIndexes("pg_stat_all_indexes")
+	return s.fetchIndexes(context.Background(), "pg_stat_all_indexes")
 }
 
 // SystemIndexes represents content of `pg_stat_system_indexes` view.
 //
 // See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-ALL-INDEXES-VIEW
 func (s *Stats) SystemIndexes() ([]IndexesRow, error) {
-	return s.fetchIndexes("pg_stat_sys_indexes")
+	return s.fetchIndexes(context.Background(), "pg_stat_sys_indexes")
 }
 
 // UserIndexes represents content of `pg_stat_user_indexes` view.
 //
 // See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-ALL-INDEXES-VIEW
 func (s *Stats) UserIndexes() ([]IndexesRow, error) {
-	return s.fetchIndexes("pg_stat_user_indexes")
+	return s.fetchIndexes(context.Background(), "pg_stat_user_indexes")
 }
 
 // IndexesRow represents schema of pg_stat_*_indexes views.
@@ -33,20 +37,33 @@ type IndexesRow struct {
 	IdxScan      *sql.NullInt64 `json:"idx_scan"`      // Number of index scans initiated on this index
 	IdxTupRead   *sql.NullInt64 `json:"idx_tup_read"`  // Number of index entries returned by scans on this index
 	IdxTupFetch  *sql.NullInt64 `json:"idx_tup_fetch"` // Number of live table rows fetched by simple index scans using this index
+	LastIdxScan  *sql.NullTime  `json:"last_idx_scan"` // The time of the last scan on this index. Supported since PostgreSQL 16.
 }
 
-func (s *Stats) fetchIndexes(view string) ([]IndexesRow, error) {
+func (s *Stats) fetchIndexes(ctx context.Context, view string) ([]IndexesRow, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lastIdxScan := "NULL"
+	if version >= 16 {
+		lastIdxScan = "last_idx_scan"
+	}
+
 	const query = `SELECT
-	relid
-	indexrelid
-	schemaname
-	relname
-	indexrelname
-	idx_scan
-	idx_tup_read
-	idx_tup_fetch from `
+	relid,
+	indexrelid,
+	schemaname,
+	relname,
+	indexrelname,
+	idx_scan,
+	idx_tup_read,
+	idx_tup_fetch,
+	%s
+	FROM %s`
 
-	rows, err := s.db.Query(query + view)
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(query, lastIdxScan, view))
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +82,7 @@ func (s *Stats) fetchIndexes(view string) ([]IndexesRow, error) {
 			&row.IdxScan,
 			&row.IdxTupRead,
 			&row.IdxTupFetch,
+			&row.LastIdxScan,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,65 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+)
+
+// PreparedXacts returns rows from a `pg_prepared_xacts` view.
+// One row per transaction that is currently prepared for two-phase commit.
+// Orphaned prepared transactions hold back the xmin horizon and keep their locks.
+//
+// See: https://www.postgresql.org/docs/current/view-pg-prepared-xacts.html
+func (s *Stats) PreparedXacts(ctx context.Context) ([]PreparedXactsRow, error) {
+	return s.fetchPreparedXacts(ctx)
+}
+
+// PreparedXactsRow represents schema of pg_prepared_xacts view
+type PreparedXactsRow struct {
+	Transaction int64           `json:"transaction"` // Numeric transaction identifier of the prepared transaction
+	Gid         string          `json:"gid"`         // Global transaction identifier that was assigned to the transaction
+	Prepared    *sql.NullTime   `json:"prepared"`    // Time at which the transaction was prepared for commit
+	Owner       *sql.NullString `json:"owner"`       // Name of the user that executed the transaction, null if the user was dropped
+	Database    *sql.NullString `json:"database"`    // Name of the database in which the transaction was executed, null if the database was dropped
+	XidAge      int64           `json:"xid_age"`     // Age of the transaction in transactions
+	AgeSeconds  float64         `json:"age_seconds"` // Time elapsed since the transaction was prepared, in seconds
+}
+
+func (s *Stats) fetchPreparedXacts(ctx context.Context) ([]PreparedXactsRow, error) {
+	const query = `SELECT
+	transaction::text::bigint,
+	gid,
+	prepared,
+	owner,
+	database,
+	age(transaction),
+	EXTRACT(EPOCH FROM now() - prepared)
+	FROM pg_prepared_xacts
+	ORDER BY prepared`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []PreparedXactsRow{}
+	for rows.Next() {
+		var row PreparedXactsRow
+
+		err := rows.Scan(
+			&row.Transaction,
+			&row.Gid,
+			&row.Prepared,
+			&row.Owner,
+			&row.Database,
+			&row.XidAge,
+			&row.AgeSeconds,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -0,0 +1,173 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// Progress returns every in-flight maintenance operation reported by `pg_stat_progress_*` views.
+// Views that are not supported by the server version are skipped.
+//
+// See: https://www.postgresql.org/docs/current/progress-reporting.html
+func (s *Stats) Progress(ctx context.Context) ([]ProgressRow, error) {
+	return s.fetchProgress(ctx)
+}
+
+// ProgressRow represents a single in-flight operation with the progress of its current phase.
+type ProgressRow struct {
+	Pid     int64            `json:"pid"`     // Process ID of backend.
+	Datname string           `json:"datname"` // Name of the database to which this backend is connected, empty for base backups.
+	Relid   int64            `json:"relid"`   // OID of the table being processed, zero if not applicable.
+	Command string           `json:"command"` // The command that is running, e.g. VACUUM, ANALYZE, CREATE INDEX, CLUSTER, COPY FROM or BASE BACKUP.
+	Phase   string           `json:"phase"`   // Current processing phase.
+	Done    *sql.NullInt64   `json:"done"`    // Amount of work done in the current phase, if the phase reports it.
+	Total   *sql.NullInt64   `json:"total"`   // Total amount of work in the current phase, if the phase reports it.
+	Percent *sql.NullFloat64 `json:"percent"` // Percent complete of the current phase, null if unknown.
+}
+
+func (s *Stats) fetchProgress(ctx context.Context) ([]ProgressRow, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []ProgressRow{}
+	if version >= 9.6 {
+		vacuum, err := s.fetchProgressVacuum(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range vacuum {
+			data = append(data, vacuumProgress(r))
+		}
+	}
+	if version >= 12 {
+		createIndex, err := s.fetchProgressCreateIndex(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range createIndex {
+			data = append(data, createIndexProgress(r))
+		}
+
+		cluster, err := s.fetchProgressCluster(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range cluster {
+			data = append(data, clusterProgress(r))
+		}
+	}
+	if version >= 13 {
+		analyze, err := s.fetchProgressAnalyze(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range analyze {
+			data = append(data, analyzeProgress(r))
+		}
+
+		basebackup, err := s.fetchProgressBasebackup(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range basebackup {
+			data = append(data, basebackupProgress(r))
+		}
+	}
+	if version >= 14 {
+		copies, err := s.fetchProgressCopy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range copies {
+			data = append(data, copyProgress(r))
+		}
+	}
+	return data, nil
+}
+
+func vacuumProgress(r ProgressVacuumRow) ProgressRow {
+	row := newProgressRow(r.Pid, r.Datname, r.Relid, "VACUUM", r.Phase)
+	switch r.Phase {
+	case "scanning heap":
+		row.setProgress(r.HeapBlksScanned, r.HeapBlksTotal)
+	case "vacuuming heap":
+		row.setProgress(r.HeapBlksVacuumed, r.HeapBlksTotal)
+	}
+	return row
+}
+
+func analyzeProgress(r ProgressAnalyzeRow) ProgressRow {
+	row := newProgressRow(r.Pid, r.Datname, r.Relid, "ANALYZE", r.Phase)
+	switch r.Phase {
+	case "acquiring sample rows":
+		row.setProgress(r.SampleBlksScanned, r.SampleBlksTotal)
+	case "acquiring inherited sample rows":
+		row.setProgress(r.ChildTablesDone, r.ChildTablesTotal)
+	case "computing extended statistics":
+		row.setProgress(r.ExtStatsComputed, r.ExtStatsTotal)
+	}
+	return row
+}
+
+func createIndexProgress(r ProgressCreateIndexRow) ProgressRow {
+	row := newProgressRow(r.Pid, r.Datname, r.Relid, r.Command, r.Phase)
+	switch {
+	case strings.HasPrefix(r.Phase, "waiting for"):
+		row.setProgress(r.LockersDone, r.LockersTotal)
+	case r.BlocksTotal != nil && r.BlocksTotal.Int64 > 0:
+		row.setProgress(r.BlocksDone, r.BlocksTotal)
+	case r.TuplesTotal != nil && r.TuplesTotal.Int64 > 0:
+		row.setProgress(r.TuplesDone, r.TuplesTotal)
+	}
+	return row
+}
+
+func clusterProgress(r ProgressClusterRow) ProgressRow {
+	row := newProgressRow(r.Pid, r.Datname, r.Relid, r.Command, r.Phase)
+	switch r.Phase {
+	case "seq scanning heap":
+		row.setProgress(r.HeapBlksScanned, r.HeapBlksTotal)
+	case "writing new heap":
+		row.setProgress(r.HeapTuplesWritten, r.HeapTuplesScanned)
+	}
+	return row
+}
+
+func copyProgress(r ProgressCopyRow) ProgressRow {
+	row := newProgressRow(r.Pid, r.Datname, r.Relid, r.Command, r.Type)
+	row.setProgress(r.BytesProcessed, r.BytesTotal)
+	return row
+}
+
+func basebackupProgress(r ProgressBasebackupRow) ProgressRow {
+	row := newProgressRow(r.Pid, "", 0, "BASE BACKUP", r.Phase)
+	switch r.Phase {
+	case "streaming database files":
+		row.setProgress(r.BackupStreamed, r.BackupTotal)
+	}
+	return row
+}
+
+// newProgressRow returns a row with unknown progress, phases which report it call setProgress.
+func newProgressRow(pid int64, datname string, relid int64, command, phase string) ProgressRow {
+	return ProgressRow{
+		Pid:     pid,
+		Datname: datname,
+		Relid:   relid,
+		Command: command,
+		Phase:   phase,
+		Percent: &sql.NullFloat64{},
+	}
+}
+
+func (r *ProgressRow) setProgress(done, total *sql.NullInt64) {
+	r.Done, r.Total = done, total
+	r.Percent = &sql.NullFloat64{}
+	if done != nil && total != nil && done.Valid && total.Valid && total.Int64 > 0 {
+		r.Percent.Float64 = 100 * float64(done.Int64) / float64(total.Int64)
+		r.Percent.Valid = true
+	}
+}
@@ -0,0 +1,89 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ProgressAnalyze returns rows from a `pg_stat_progress_analyze` view.
+// One row for each backend (including autovacuum worker processes) running ANALYZE. Supported since PostgreSQL 13.
+//
+// See: https://www.postgresql.org/docs/current/progress-reporting.html#ANALYZE-PROGRESS-REPORTING
+func (s *Stats) ProgressAnalyze() ([]ProgressAnalyzeRow, error) {
+	return s.fetchProgressAnalyze(context.Background())
+}
+
+// ProgressAnalyzeRow represents schema of pg_stat_progress_analyze view
+type ProgressAnalyzeRow struct {
+	Pid                    int64          `json:"pid"`                       // Process ID of backend.
+	Datid                  int64          `json:"datid"`                     // OID of the database to which this backend is connected.
+	Datname                string         `json:"datname"`                   // Name of the database to which this backend is connected.
+	Relid                  int64          `json:"relid"`                     // OID of the table being analyzed.
+	Phase                  string         `json:"phase"`                     // Current processing phase.
+	SampleBlksTotal        *sql.NullInt64 `json:"sample_blks_total"`         // Total number of heap blocks that will be sampled.
+	SampleBlksScanned      *sql.NullInt64 `json:"sample_blks_scanned"`       // Number of heap blocks scanned.
+	ExtStatsTotal          *sql.NullInt64 `json:"ext_stats_total"`           // Number of extended statistics.
+	ExtStatsComputed       *sql.NullInt64 `json:"ext_stats_computed"`        // Number of extended statistics computed.
+	ChildTablesTotal       *sql.NullInt64 `json:"child_tables_total"`        // Number of child tables.
+	ChildTablesDone        *sql.NullInt64 `json:"child_tables_done"`         // Number of child tables scanned.
+	CurrentChildTableRelid *sql.NullInt64 `json:"current_child_table_relid"` // OID of the child table currently being scanned.
+}
+
+func (s *Stats) fetchProgressAnalyze(ctx context.Context) ([]ProgressAnalyzeRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 13:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	default:
+		// pass
+	}
+
+	const query = `SELECT
+	pid,
+	datid,
+	datname,
+	relid,
+	phase,
+	sample_blks_total,
+	sample_blks_scanned,
+	ext_stats_total,
+	ext_stats_computed,
+	child_tables_total,
+	child_tables_done,
+	current_child_table_relid
+	FROM pg_stat_progress_analyze`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ProgressAnalyzeRow{}
+	for rows.Next() {
+		var row ProgressAnalyzeRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Datid,
+			&row.Datname,
+			&row.Relid,
+			&row.Phase,
+			&row.SampleBlksTotal,
+			&row.SampleBlksScanned,
+			&row.ExtStatsTotal,
+			&row.ExtStatsComputed,
+			&row.ChildTablesTotal,
+			&row.ChildTablesDone,
+			&row.CurrentChildTableRelid,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -0,0 +1,71 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ProgressBasebackup returns rows from a `pg_stat_progress_basebackup` view.
+// One row for each WAL sender process streaming a base backup. Supported since PostgreSQL 13.
+//
+// See: https://www.postgresql.org/docs/current/progress-reporting.html#BASEBACKUP-PROGRESS-REPORTING
+func (s *Stats) ProgressBasebackup() ([]ProgressBasebackupRow, error) {
+	return s.fetchProgressBasebackup(context.Background())
+}
+
+// ProgressBasebackupRow represents schema of pg_stat_progress_basebackup view
+type ProgressBasebackupRow struct {
+	Pid                 int64          `json:"pid"`                  // Process ID of a WAL sender process.
+	Phase               string         `json:"phase"`                // Current processing phase.
+	BackupTotal         *sql.NullInt64 `json:"backup_total"`         // Total amount of data that will be streamed, or NULL if the estimation is disabled.
+	BackupStreamed      *sql.NullInt64 `json:"backup_streamed"`      // Amount of data streamed.
+	TablespacesTotal    *sql.NullInt64 `json:"tablespaces_total"`    // Total number of tablespaces that will be streamed.
+	TablespacesStreamed *sql.NullInt64 `json:"tablespaces_streamed"` // Number of tablespaces streamed.
+}
+
+func (s *Stats) fetchProgressBasebackup(ctx context.Context) ([]ProgressBasebackupRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 13:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	default:
+		// pass
+	}
+
+	const query = `SELECT
+	pid,
+	phase,
+	backup_total,
+	backup_streamed,
+	tablespaces_total,
+	tablespaces_streamed
+	FROM pg_stat_progress_basebackup`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ProgressBasebackupRow{}
+	for rows.Next() {
+		var row ProgressBasebackupRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Phase,
+			&row.BackupTotal,
+			&row.BackupStreamed,
+			&row.TablespacesTotal,
+			&row.TablespacesStreamed,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -0,0 +1,89 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ProgressCluster returns rows from a `pg_stat_progress_cluster` view.
+// One row for each backend running CLUSTER or VACUUM FULL. Supported since PostgreSQL 12.
+//
+// See: https://www.postgresql.org/docs/current/progress-reporting.html#CLUSTER-PROGRESS-REPORTING
+func (s *Stats) ProgressCluster() ([]ProgressClusterRow, error) {
+	return s.fetchProgressCluster(context.Background())
+}
+
+// ProgressClusterRow represents schema of pg_stat_progress_cluster view
+type ProgressClusterRow struct {
+	Pid               int64          `json:"pid"`                 // Process ID of backend.
+	Datid             int64          `json:"datid"`               // OID of the database to which this backend is connected.
+	Datname           string         `json:"datname"`             // Name of the database to which this backend is connected.
+	Relid             int64          `json:"relid"`               // OID of the table being clustered.
+	Command           string         `json:"command"`             // The command that is running. Either CLUSTER or VACUUM FULL.
+	Phase             string         `json:"phase"`               // Current processing phase.
+	ClusterIndexRelid *sql.NullInt64 `json:"cluster_index_relid"` // If the table is being scanned using an index, this is the OID of the index being used; otherwise, it is zero.
+	HeapTuplesScanned *sql.NullInt64 `json:"heap_tuples_scanned"` // Number of heap tuples scanned.
+	HeapTuplesWritten *sql.NullInt64 `json:"heap_tuples_written"` // Number of heap tuples written.
+	HeapBlksTotal     *sql.NullInt64 `json:"heap_blks_total"`     // Total number of heap blocks in the table.
+	HeapBlksScanned   *sql.NullInt64 `json:"heap_blks_scanned"`   // Number of heap blocks scanned.
+	IndexRebuildCount *sql.NullInt64 `json:"index_rebuild_count"` // Number of indexes rebuilt.
+}
+
+func (s *Stats) fetchProgressCluster(ctx context.Context) ([]ProgressClusterRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 12:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	default:
+		// pass
+	}
+
+	const query = `SELECT
+	pid,
+	datid,
+	datname,
+	relid,
+	command,
+	phase,
+	cluster_index_relid,
+	heap_tuples_scanned,
+	heap_tuples_written,
+	heap_blks_total,
+	heap_blks_scanned,
+	index_rebuild_count
+	FROM pg_stat_progress_cluster`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ProgressClusterRow{}
+	for rows.Next() {
+		var row ProgressClusterRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Datid,
+			&row.Datname,
+			&row.Relid,
+			&row.Command,
+			&row.Phase,
+			&row.ClusterIndexRelid,
+			&row.HeapTuplesScanned,
+			&row.HeapTuplesWritten,
+			&row.HeapBlksTotal,
+			&row.HeapBlksScanned,
+			&row.IndexRebuildCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -0,0 +1,83 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ProgressCopy returns rows from a `pg_stat_progress_copy` view.
+// One row for each backend running COPY. Supported since PostgreSQL 14.
+//
+// See: https://www.postgresql.org/docs/current/progress-reporting.html#COPY-PROGRESS-REPORTING
+func (s *Stats) ProgressCopy() ([]ProgressCopyRow, error) {
+	return s.fetchProgressCopy(context.Background())
+}
+
+// ProgressCopyRow represents schema of pg_stat_progress_copy view
+type ProgressCopyRow struct {
+	Pid             int64          `json:"pid"`              // Process ID of backend.
+	Datid           int64          `json:"datid"`            // OID of the database to which this backend is connected.
+	Datname         string         `json:"datname"`          // Name of the database to which this backend is connected.
+	Relid           int64          `json:"relid"`            // OID of the table on which the COPY command is executed. It is set to 0 if copying from a SELECT query.
+	Command         string         `json:"command"`          // The command that is running: COPY FROM, or COPY TO.
+	Type            string         `json:"type"`             // The io type that the data is read from or written to: FILE, PROGRAM, PIPE (for COPY FROM STDIN and COPY TO STDOUT), or CALLBACK.
+	BytesProcessed  *sql.NullInt64 `json:"bytes_processed"`  // Number of bytes already processed by COPY command.
+	BytesTotal      *sql.NullInt64 `json:"bytes_total"`      // Size of source file for COPY FROM command in bytes. It is set to 0 if not available.
+	TuplesProcessed *sql.NullInt64 `json:"tuples_processed"` // Number of tuples already processed by COPY command.
+	TuplesExcluded  *sql.NullInt64 `json:"tuples_excluded"`  // Number of tuples not processed because they were excluded by the WHERE clause of the COPY command.
+}
+
+func (s *Stats) fetchProgressCopy(ctx context.Context) ([]ProgressCopyRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 14:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	default:
+		// pass
+	}
+
+	const query = `SELECT
+	pid,
+	datid,
+	datname,
+	relid,
+	command,
+	type,
+	bytes_processed,
+	bytes_total,
+	tuples_processed,
+	tuples_excluded
+	FROM pg_stat_progress_copy`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ProgressCopyRow{}
+	for rows.Next() {
+		var row ProgressCopyRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Datid,
+			&row.Datname,
+			&row.Relid,
+			&row.Command,
+			&row.Type,
+			&row.BytesProcessed,
+			&row.BytesTotal,
+			&row.TuplesProcessed,
+			&row.TuplesExcluded,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
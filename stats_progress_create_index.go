@@ -0,0 +1,101 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ProgressCreateIndex returns rows from a `pg_stat_progress_create_index` view.
+// One row for each backend running CREATE INDEX or REINDEX. Supported since PostgreSQL 12.
+//
+// See: https://www.postgresql.org/docs/current/progress-reporting.html#CREATE-INDEX-PROGRESS-REPORTING
+func (s *Stats) ProgressCreateIndex() ([]ProgressCreateIndexRow, error) {
+	return s.fetchProgressCreateIndex(context.Background())
+}
+
+// ProgressCreateIndexRow represents schema of pg_stat_progress_create_index view
+type ProgressCreateIndexRow struct {
+	Pid              int64          `json:"pid"`                // Process ID of backend.
+	Datid            int64          `json:"datid"`              // OID of the database to which this backend is connected.
+	Datname          string         `json:"datname"`            // Name of the database to which this backend is connected.
+	Relid            int64          `json:"relid"`              // OID of the table on which the index is being created.
+	IndexRelid       *sql.NullInt64 `json:"index_relid"`        // OID of the index being created or reindexed.
+	Command          string         `json:"command"`            // Specific command type: CREATE INDEX, CREATE INDEX CONCURRENTLY, REINDEX, or REINDEX CONCURRENTLY.
+	Phase            string         `json:"phase"`              // Current processing phase of index creation.
+	LockersTotal     *sql.NullInt64 `json:"lockers_total"`      // Total number of lockers to wait for, when applicable.
+	LockersDone      *sql.NullInt64 `json:"lockers_done"`       // Number of lockers already waited for.
+	CurrentLockerPid *sql.NullInt64 `json:"current_locker_pid"` // Process ID of the locker currently being waited for.
+	BlocksTotal      *sql.NullInt64 `json:"blocks_total"`       // Total number of blocks to be processed in the current phase.
+	BlocksDone       *sql.NullInt64 `json:"blocks_done"`        // Number of blocks already processed in the current phase.
+	TuplesTotal      *sql.NullInt64 `json:"tuples_total"`       // Total number of tuples to be processed in the current phase.
+	TuplesDone       *sql.NullInt64 `json:"tuples_done"`        // Number of tuples already processed in the current phase.
+	PartitionsTotal  *sql.NullInt64 `json:"partitions_total"`   // When creating an index on a partitioned table, this column is set to the total number of partitions on which the index is to be created.
+	PartitionsDone   *sql.NullInt64 `json:"partitions_done"`    // When creating an index on a partitioned table, this column is set to the number of partitions on which the index has been created.
+}
+
+func (s *Stats) fetchProgressCreateIndex(ctx context.Context) ([]ProgressCreateIndexRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 12:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	default:
+		// pass
+	}
+
+	const query = `SELECT
+	pid,
+	datid,
+	datname,
+	relid,
+	index_relid,
+	command,
+	phase,
+	lockers_total,
+	lockers_done,
+	current_locker_pid,
+	blocks_total,
+	blocks_done,
+	tuples_total,
+	tuples_done,
+	partitions_total,
+	partitions_done
+	FROM pg_stat_progress_create_index`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ProgressCreateIndexRow{}
+	for rows.Next() {
+		var row ProgressCreateIndexRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Datid,
+			&row.Datname,
+			&row.Relid,
+			&row.IndexRelid,
+			&row.Command,
+			&row.Phase,
+			&row.LockersTotal,
+			&row.LockersDone,
+			&row.CurrentLockerPid,
+			&row.BlocksTotal,
+			&row.BlocksDone,
+			&row.TuplesTotal,
+			&row.TuplesDone,
+			&row.PartitionsTotal,
+			&row.PartitionsDone,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -1,8 +1,10 @@
 package pgstats
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 // ProgressVacuum represents content of `pg_stat_progress_vacuum` view.
@@ -10,50 +12,129 @@ import (
 //
 // See: https://www.postgresql.org/docs/current/progress-reporting.html#VACUUM-PROGRESS-REPORTING
 func (s *Stats) ProgressVacuum() ([]ProgressVacuumRow, error) {
-	return s.fetchProgressVacuum()
+	return s.fetchProgressVacuum(context.Background())
 }
 
 // ProgressVacuumRow represents schema of pg_stat_progress_vacuum view
 type ProgressVacuumRow struct {
-	Pid              int64          `json:"pid"`                // Process ID of backend.
-	Datid            int64          `json:"datid"`              // OID of the database to which this backend is connected.
-	Datname          string         `json:"datname"`            // Name of the database to which this backend is connected.
-	Relid            int64          `json:"relid"`              // OID of the table being vacuumed.
-	Phase            string         `json:"phase"`              // Current processing phase of vacuum.
-	HeapBlksTotal    *sql.NullInt64 `json:"heap_blks_total"`    // Total number of heap blocks in the table.
-	HeapBlksScanned  *sql.NullInt64 `json:"heap_blks_scanned"`  // Number of heap blocks scanned.
-	HeapBlksVacuumed *sql.NullInt64 `json:"heap_blks_vacuumed"` // Number of heap blocks vacuumed.
-	IndexVacuumCount *sql.NullInt64 `json:"index_vacuum_count"` // Number of completed index vacuum cycles.
-	MaxDeadTuples    *sql.NullInt64 `json:"max_dead_tuples"`    // Number of dead tuples that we can store before needing to perform an index vacuum cycle, based on maintenance_work_mem.
-	NumDeadTuples    *sql.NullInt64 `json:"num_dead_tuples"`    // Number of dead tuples collected since the last index vacuum cycle.
+	Pid               int64           `json:"pid"`                  // Process ID of backend.
+	Datid             int64           `json:"datid"`                // OID of the database to which this backend is connected.
+	Datname           string          `json:"datname"`              // Name of the database to which this backend is connected.
+	Relid             int64           `json:"relid"`                // OID of the table being vacuumed.
+	Schemaname        *sql.NullString `json:"schemaname"`           // Name of the schema of the table being vacuumed, null if the table is in another database.
+	Relname           *sql.NullString `json:"relname"`              // Name of the table being vacuumed, null if the table is in another database.
+	RelationSize      *sql.NullInt64  `json:"relation_size"`        // Size of the main fork of the table being vacuumed in bytes, null if the table is in another database or sizes are disabled.
+	Phase             string          `json:"phase"`                // Current processing phase of vacuum.
+	HeapBlksTotal     *sql.NullInt64  `json:"heap_blks_total"`      // Total number of heap blocks in the table.
+	HeapBlksScanned   *sql.NullInt64  `json:"heap_blks_scanned"`    // Number of heap blocks scanned.
+	HeapBlksVacuumed  *sql.NullInt64  `json:"heap_blks_vacuumed"`   // Number of heap blocks vacuumed.
+	IndexVacuumCount  *sql.NullInt64  `json:"index_vacuum_count"`   // Number of completed index vacuum cycles.
+	MaxDeadTuples     *sql.NullInt64  `json:"max_dead_tuples"`      // Number of dead tuples that we can store before needing to perform an index vacuum cycle, based on maintenance_work_mem. Supported until PostgreSQL 16 (inclusive).
+	NumDeadTuples     *sql.NullInt64  `json:"num_dead_tuples"`      // Number of dead tuples collected since the last index vacuum cycle. Supported until PostgreSQL 16 (inclusive).
+	MaxDeadTupleBytes *sql.NullInt64  `json:"max_dead_tuple_bytes"` // Amount of dead tuple data that we can store before needing to perform an index vacuum cycle, based on maintenance_work_mem. Supported since PostgreSQL 17.
+	DeadTupleBytes    *sql.NullInt64  `json:"dead_tuple_bytes"`     // Amount of dead tuple data collected since the last index vacuum cycle. Supported since PostgreSQL 17.
+	NumDeadItemIds    *sql.NullInt64  `json:"num_dead_item_ids"`    // Number of dead item identifiers collected since the last index vacuum cycle. Supported since PostgreSQL 17.
+	IndexesTotal      *sql.NullInt64  `json:"indexes_total"`        // Total number of indexes that will be vacuumed or cleaned up. Supported since PostgreSQL 17.
+	IndexesProcessed  *sql.NullInt64  `json:"indexes_processed"`    // Number of indexes processed. Supported since PostgreSQL 17.
 }
 
-func (s *Stats) fetchProgressVacuum() ([]ProgressVacuumRow, error) {
-	version, err := s.getVersion()
+func (s *Stats) fetchProgressVacuum(ctx context.Context) ([]ProgressVacuumRow, error) {
+	version, err := s.getVersionContext(ctx)
 	switch {
 	case err != nil:
 		return nil, err
-	case version < 9.6:
-		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	case version >= 17:
+		return s.fetchProgressVacuum17(ctx)
+	case version >= 9.6:
+		return s.fetchProgressVacuum96(ctx)
 	default:
-		// pass
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	}
+}
+
+func (s *Stats) fetchProgressVacuum17(ctx context.Context) ([]ProgressVacuumRow, error) {
+	const query = `SELECT
+	p.pid,
+	p.datid,
+	p.datname,
+	p.relid,
+	n.nspname,
+	c.relname,
+	CASE WHEN $1 AND c.oid IS NOT NULL THEN pg_relation_size(c.oid) END,
+	p.phase,
+	p.heap_blks_total,
+	p.heap_blks_scanned,
+	p.heap_blks_vacuumed,
+	p.index_vacuum_count,
+	p.max_dead_tuple_bytes,
+	p.dead_tuple_bytes,
+	p.num_dead_item_ids,
+	p.indexes_total,
+	p.indexes_processed
+	FROM pg_stat_progress_vacuum p
+	LEFT JOIN pg_class c ON c.oid = p.relid
+		AND p.datid = (SELECT oid FROM pg_database WHERE datname = current_database())
+	LEFT JOIN pg_namespace n ON n.oid = c.relnamespace`
+
+	rows, err := s.db.QueryContext(ctx, query, !s.noSizes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ProgressVacuumRow{}
+	for rows.Next() {
+		var row ProgressVacuumRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.Datid,
+			&row.Datname,
+			&row.Relid,
+			&row.Schemaname,
+			&row.Relname,
+			&row.RelationSize,
+			&row.Phase,
+			&row.HeapBlksTotal,
+			&row.HeapBlksScanned,
+			&row.HeapBlksVacuumed,
+			&row.IndexVacuumCount,
+			&row.MaxDeadTupleBytes,
+			&row.DeadTupleBytes,
+			&row.NumDeadItemIds,
+			&row.IndexesTotal,
+			&row.IndexesProcessed,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
 	}
+	return data, rows.Err()
+}
 
+func (s *Stats) fetchProgressVacuum96(ctx context.Context) ([]ProgressVacuumRow, error) {
 	const query = `SELECT
-	pid,
-	datid,
-	datname,
-	relid,
-	phase,
-	heap_blks_total,
-	heap_blks_scanned,
-	heap_blks_vacuumed,
-	index_vacuum_count,
-	max_dead_tuples,
-	num_dead_tuples
-	FROM pg_stat_progress_vacuum`
-
-	rows, err := s.db.Query(query)
+	p.pid,
+	p.datid,
+	p.datname,
+	p.relid,
+	n.nspname,
+	c.relname,
+	CASE WHEN $1 AND c.oid IS NOT NULL THEN pg_relation_size(c.oid) END,
+	p.phase,
+	p.heap_blks_total,
+	p.heap_blks_scanned,
+	p.heap_blks_vacuumed,
+	p.index_vacuum_count,
+	p.max_dead_tuples,
+	p.num_dead_tuples
+	FROM pg_stat_progress_vacuum p
+	LEFT JOIN pg_class c ON c.oid = p.relid
+		AND p.datid = (SELECT oid FROM pg_database WHERE datname = current_database())
+	LEFT JOIN pg_namespace n ON n.oid = c.relnamespace`
+
+	rows, err := s.db.QueryContext(ctx, query, !s.noSizes)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +149,9 @@ func (s *Stats) fetchProgressVacuum() ([]ProgressVacuumRow, error) {
 			&row.Datid,
 			&row.Datname,
 			&row.Relid,
+			&row.Schemaname,
+			&row.Relname,
+			&row.RelationSize,
 			&row.Phase,
 			&row.HeapBlksTotal,
 			&row.HeapBlksScanned,
@@ -83,3 +167,84 @@ func (s *Stats) fetchProgressVacuum() ([]ProgressVacuumRow, error) {
 	}
 	return data, rows.Err()
 }
+
+// VacuumSampler estimates remaining time of running vacuums from successive observations of pg_stat_progress_vacuum.
+// It is not safe for concurrent use.
+type VacuumSampler struct {
+	first map[int64]vacuumSample
+}
+
+type vacuumSample struct {
+	relid   int64
+	scanned int64
+	at      time.Time
+}
+
+// VacuumEstimate represents estimated progress of a single vacuum.
+type VacuumEstimate struct {
+	Pid              int64            `json:"pid"`               // Process ID of backend.
+	Relid            int64            `json:"relid"`             // OID of the table being vacuumed.
+	Phase            string           `json:"phase"`             // Current processing phase of vacuum.
+	BlocksPerSecond  *sql.NullFloat64 `json:"blocks_per_second"` // Observed heap scan rate, null until the second observation.
+	RemainingSeconds *sql.NullFloat64 `json:"remaining_seconds"` // Estimated time to finish scanning the heap, null if unknown.
+}
+
+// NewVacuumSampler creates a new VacuumSampler.
+func NewVacuumSampler() *VacuumSampler {
+	return &VacuumSampler{
+		first: map[int64]vacuumSample{},
+	}
+}
+
+// Add records rows observed at the given time and returns an estimate for each of them.
+// Vacuums which are not present in rows are forgotten.
+func (vs *VacuumSampler) Add(rows []ProgressVacuumRow, at time.Time) []VacuumEstimate {
+	seen := make(map[int64]bool, len(rows))
+	estimates := make([]VacuumEstimate, 0, len(rows))
+
+	for _, row := range rows {
+		seen[row.Pid] = true
+		est := VacuumEstimate{
+			Pid:              row.Pid,
+			Relid:            row.Relid,
+			Phase:            row.Phase,
+			BlocksPerSecond:  &sql.NullFloat64{},
+			RemainingSeconds: &sql.NullFloat64{},
+		}
+
+		if row.HeapBlksScanned == nil || !row.HeapBlksScanned.Valid {
+			estimates = append(estimates, est)
+			continue
+		}
+		curr := vacuumSample{relid: row.Relid, scanned: row.HeapBlksScanned.Int64, at: at}
+
+		first, ok := vs.first[row.Pid]
+		if !ok || first.relid != curr.relid || first.scanned > curr.scanned {
+			vs.first[row.Pid] = curr
+			estimates = append(estimates, est)
+			continue
+		}
+
+		elapsed := curr.at.Sub(first.at).Seconds()
+		if elapsed > 0 {
+			rate := float64(curr.scanned-first.scanned) / elapsed
+			est.BlocksPerSecond.Float64, est.BlocksPerSecond.Valid = rate, true
+
+			if rate > 0 && row.HeapBlksTotal != nil && row.HeapBlksTotal.Valid {
+				left := row.HeapBlksTotal.Int64 - curr.scanned
+				if left < 0 {
+					left = 0
+				}
+				est.RemainingSeconds.Float64, est.RemainingSeconds.Valid = float64(left)/rate, true
+			}
+		}
+		estimates = append(estimates, est)
+	}
+
+	for pid := range vs.first {
+		if !seen[pid] {
+			delete(vs.first, pid)
+		}
+	}
+	return estimates
+}
@@ -0,0 +1,84 @@
+package pgstats
+
+import (
+	"database/sql"
+)
+
+// RecoveryStatus returns the recovery state of the server combined with `pg_stat_wal_receiver` view.
+// On a primary server InRecovery is false and the rest of the fields are empty.
+//
+// See: https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-RECOVERY-CONTROL
+func (s *Stats) RecoveryStatus() (RecoveryStatusView, error) {
+	return s.fetchRecoveryStatus()
+}
+
+// RecoveryStatusView represents recovery state of a standby server.
+// LSN values are reported as byte positions.
+type RecoveryStatusView struct {
+	InRecovery              bool             `json:"in_recovery"`                // True if recovery is still in progress
+	LastReceiveLsn          *sql.NullInt64   `json:"last_receive_lsn"`           // Last write-ahead log location received and synced to disk by streaming replication
+	LastReplayLsn           *sql.NullInt64   `json:"last_replay_lsn"`            // Last write-ahead log location replayed during recovery
+	LastXactReplayTimestamp *sql.NullTime    `json:"last_xact_replay_timestamp"` // Time stamp of the last transaction replayed during recovery
+	ReplayPaused            *sql.NullBool    `json:"replay_paused"`              // True if recovery is paused
+	ReplayLagBytes          *sql.NullInt64   `json:"replay_lag_bytes"`           // Amount of received WAL which is not yet replayed
+	ReplayLagSeconds        *sql.NullFloat64 `json:"replay_lag_seconds"`         // Time since the last replayed transaction, zero when all received WAL is replayed
+	WalReceiver             *WalReceiverView `json:"wal_receiver"`               // Content of pg_stat_wal_receiver view, nil if there is no WAL receiver. Supported since PostgreSQL 9.6.
+}
+
+func (s *Stats) fetchRecoveryStatus() (RecoveryStatusView, error) {
+	version, err := s.getVersion()
+	if err != nil {
+		return RecoveryStatusView{}, err
+	}
+
+	query := recoveryStatusQuery10
+	if version < 10 {
+		query = recoveryStatusQuery96
+	}
+
+	row := s.db.QueryRow(query)
+	var res RecoveryStatusView
+
+	err = row.Scan(
+		&res.InRecovery,
+		&res.LastReceiveLsn,
+		&res.LastReplayLsn,
+		&res.LastXactReplayTimestamp,
+		&res.ReplayPaused,
+		&res.ReplayLagBytes,
+		&res.ReplayLagSeconds,
+	)
+	if err != nil || !res.InRecovery || version < 9.6 {
+		return res, err
+	}
+
+	walReceiver, err := s.fetchWalReceiver()
+	switch {
+	case err == ErrNotStandby:
+		return res, nil
+	case err != nil:
+		return res, err
+	}
+	res.WalReceiver = &walReceiver
+	return res, nil
+}
+
+const recoveryStatusQuery10 = `SELECT
+	pg_is_in_recovery(),
+	(pg_last_wal_receive_lsn() - '0/0')::bigint,
+	(pg_last_wal_replay_lsn() - '0/0')::bigint,
+	pg_last_xact_replay_timestamp(),
+	CASE WHEN pg_is_in_recovery() THEN pg_is_wal_replay_paused() END,
+	(pg_last_wal_receive_lsn() - pg_last_wal_replay_lsn())::bigint,
+	CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) END`
+
+const recoveryStatusQuery96 = `SELECT
+	pg_is_in_recovery(),
+	(pg_last_xlog_receive_location() - '0/0')::bigint,
+	(pg_last_xlog_replay_location() - '0/0')::bigint,
+	pg_last_xact_replay_timestamp(),
+	CASE WHEN pg_is_in_recovery() THEN pg_is_xlog_replay_paused() END,
+	(pg_last_xlog_receive_location() - pg_last_xlog_replay_location())::bigint,
+	CASE WHEN pg_last_xlog_receive_location() = pg_last_xlog_replay_location() THEN 0
+	ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) END`
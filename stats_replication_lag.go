@@ -0,0 +1,112 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ReplicationLag returns replication lag of every connected standby as seen from the primary.
+// Byte lags are computed against the current WAL position, read in the same query as `pg_stat_replication`.
+// On a cascading standby the last received WAL position is used instead.
+//
+// See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-REPLICATION-VIEW
+func (s *Stats) ReplicationLag(ctx context.Context) ([]ReplicationLagRow, error) {
+	return s.fetchReplicationLag(ctx)
+}
+
+// ReplicationLagRow represents replication lag of a single standby.
+type ReplicationLagRow struct {
+	Pid              int64            `json:"pid"`                // Process ID of a WAL sender process
+	ApplicationName  *sql.NullString  `json:"application_name"`   // Name of the application that is connected to this WAL sender
+	ClientAddr       *sql.NullString  `json:"client_addr"`        // IP address of the client connected to this WAL sender.
+	State            *sql.NullString  `json:"state"`              // Current WAL sender state.
+	SyncState        *sql.NullString  `json:"sync_state"`         // Synchronous state of this standby server.
+	CurrentLsn       *sql.NullInt64   `json:"current_lsn"`        // Current write-ahead log write location on the primary, or last received location on a standby, as a byte position
+	SentLagBytes     *sql.NullInt64   `json:"sent_lag_bytes"`     // Amount of WAL generated but not yet sent to this standby
+	WriteLagBytes    *sql.NullInt64   `json:"write_lag_bytes"`    // Amount of WAL not yet written to disk by this standby
+	FlushLagBytes    *sql.NullInt64   `json:"flush_lag_bytes"`    // Amount of WAL not yet flushed to disk by this standby
+	ReplayLagBytes   *sql.NullInt64   `json:"replay_lag_bytes"`   // Amount of WAL not yet replayed by this standby
+	WriteLagSeconds  *sql.NullFloat64 `json:"write_lag_seconds"`  // Value of write_lag in seconds. Supported since PostgreSQL 10.
+	FlushLagSeconds  *sql.NullFloat64 `json:"flush_lag_seconds"`  // Value of flush_lag in seconds. Supported since PostgreSQL 10.
+	ReplayLagSeconds *sql.NullFloat64 `json:"replay_lag_seconds"` // Value of replay_lag in seconds. Supported since PostgreSQL 10.
+}
+
+func (s *Stats) fetchReplicationLag(ctx context.Context) ([]ReplicationLagRow, error) {
+	version, err := s.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 10:
+		return s.fetchReplicationLagQuery(ctx, replicationLagQuery96)
+	default:
+		return s.fetchReplicationLagQuery(ctx, replicationLagQuery10)
+	}
+}
+
+const replicationLagQuery10 = `SELECT
+	pid,
+	application_name,
+	client_addr,
+	state,
+	sync_state,
+	(c.lsn - '0/0')::bigint,
+	(c.lsn - sent_lsn)::bigint,
+	(c.lsn - write_lsn)::bigint,
+	(c.lsn - flush_lsn)::bigint,
+	(c.lsn - replay_lsn)::bigint,
+	EXTRACT(EPOCH FROM write_lag),
+	EXTRACT(EPOCH FROM flush_lag),
+	EXTRACT(EPOCH FROM replay_lag)
+	FROM pg_stat_replication,
+	(SELECT CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END AS lsn) c`
+
+const replicationLagQuery96 = `SELECT
+	pid,
+	application_name,
+	client_addr,
+	state,
+	sync_state,
+	(c.lsn - '0/0')::bigint,
+	(c.lsn - sent_location)::bigint,
+	(c.lsn - write_location)::bigint,
+	(c.lsn - flush_location)::bigint,
+	(c.lsn - replay_location)::bigint,
+	NULL,
+	NULL,
+	NULL
+	FROM pg_stat_replication,
+	(SELECT CASE WHEN pg_is_in_recovery() THEN pg_last_xlog_receive_location() ELSE pg_current_xlog_location() END AS lsn) c`
+
+func (s *Stats) fetchReplicationLagQuery(ctx context.Context, query string) ([]ReplicationLagRow, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ReplicationLagRow{}
+	for rows.Next() {
+		var row ReplicationLagRow
+
+		err := rows.Scan(
+			&row.Pid,
+			&row.ApplicationName,
+			&row.ClientAddr,
+			&row.State,
+			&row.SyncState,
+			&row.CurrentLsn,
+			&row.SentLagBytes,
+			&row.WriteLagBytes,
+			&row.FlushLagBytes,
+			&row.ReplayLagBytes,
+			&row.WriteLagSeconds,
+			&row.FlushLagSeconds,
+			&row.ReplayLagSeconds,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -0,0 +1,190 @@
+package pgstats
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ReplicationSlots returns rows from a `pg_replication_slots` view joined with `pg_stat_replication_slots` (PostgreSQL 14+).
+// One row per replication slot currently existing on the database cluster, along with its current state.
+//
+// See: https://www.postgresql.org/docs/current/view-pg-replication-slots.html
+// See: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-REPLICATION-SLOTS-VIEW
+func (s *Stats) ReplicationSlots() ([]ReplicationSlotsRow, error) {
+	return s.fetchReplicationSlots()
+}
+
+// ReplicationSlotsRow represents schema of pg_replication_slots and pg_stat_replication_slots views.
+// LSN columns are reported as byte positions.
+type ReplicationSlotsRow struct {
+	SlotName          string          `json:"slot_name"`           // A unique, cluster-wide identifier for the replication slot
+	Plugin            *sql.NullString `json:"plugin"`              // The base name of the shared object containing the output plugin this logical slot is using, or null for physical slots.
+	SlotType          string          `json:"slot_type"`           // The slot type: physical or logical
+	Datoid            *sql.NullInt64  `json:"datoid"`              // The OID of the database this slot is associated with, or null. Only logical slots have an associated database.
+	Database          *sql.NullString `json:"database"`            // The name of the database this slot is associated with, or null. Only logical slots have an associated database.
+	Temporary         *sql.NullBool   `json:"temporary"`           // True if this is a temporary replication slot. Supported since PostgreSQL 10.
+	Active            bool            `json:"active"`              // True if this slot is currently actively being used
+	ActivePid         *sql.NullInt64  `json:"active_pid"`          // The process ID of the session using this slot if the slot is currently actively being used.
+	Xmin              *sql.NullInt64  `json:"xmin"`                // The oldest transaction that this slot needs the database to retain.
+	CatalogXmin       *sql.NullInt64  `json:"catalog_xmin"`        // The oldest transaction affecting the system catalogs that this slot needs the database to retain.
+	RestartLsn        *sql.NullInt64  `json:"restart_lsn"`         // The address (LSN) of oldest WAL which still might be required by the consumer of this slot
+	ConfirmedFlushLsn *sql.NullInt64  `json:"confirmed_flush_lsn"` // The address (LSN) up to which the logical slot's consumer has confirmed receiving data.
+	WalStatus         *sql.NullString `json:"wal_status"`          // Availability of WAL files claimed by this slot. Supported since PostgreSQL 13.
+	SafeWalSize       *sql.NullInt64  `json:"safe_wal_size"`       // The number of bytes that can be written to WAL such that this slot is not in danger of getting in state "lost". Supported since PostgreSQL 13.
+	SpillTxns         *sql.NullInt64  `json:"spill_txns"`          // Number of transactions spilled to disk once the memory used by logical decoding exceeds logical_decoding_work_mem. Supported since PostgreSQL 14.
+	SpillCount        *sql.NullInt64  `json:"spill_count"`         // Number of times transactions were spilled to disk while decoding changes from WAL for this slot. Supported since PostgreSQL 14.
+	SpillBytes        *sql.NullInt64  `json:"spill_bytes"`         // Amount of decoded transaction data spilled to disk while performing decoding of changes from WAL for this slot. Supported since PostgreSQL 14.
+	StreamTxns        *sql.NullInt64  `json:"stream_txns"`         // Number of in-progress transactions streamed to the decoding output plugin. Supported since PostgreSQL 14.
+	StreamCount       *sql.NullInt64  `json:"stream_count"`        // Number of times in-progress transactions were streamed to the decoding output plugin. Supported since PostgreSQL 14.
+	StreamBytes       *sql.NullInt64  `json:"stream_bytes"`        // Amount of transaction data decoded for streaming in-progress transactions. Supported since PostgreSQL 14.
+	TotalTxns         *sql.NullInt64  `json:"total_txns"`          // Number of decoded transactions sent to the decoding output plugin for this slot. Supported since PostgreSQL 14.
+	TotalBytes        *sql.NullInt64  `json:"total_bytes"`         // Amount of transaction data decoded for sending transactions to the decoding output plugin. Supported since PostgreSQL 14.
+	StatsReset        *sql.NullTime   `json:"stats_reset"`         // Time at which these statistics were last reset. Supported since PostgreSQL 14.
+	RetainedWalBytes  *sql.NullInt64  `json:"retained_wal_bytes"`  // Amount of WAL between restart_lsn and the current WAL position which is retained by this slot.
+}
+
+func (s *Stats) fetchReplicationSlots() ([]ReplicationSlotsRow, error) {
+	version, err := s.getVersion()
+	switch {
+	case err != nil:
+		return nil, err
+	case version >= 14:
+		return s.fetchReplicationSlotsQuery(replicationSlotsQuery14)
+	case version == 13:
+		return s.fetchReplicationSlotsQuery(replicationSlotsQuery13)
+	case version >= 10:
+		return s.fetchReplicationSlotsQuery(replicationSlotsQuery10)
+	case version == 9.6:
+		return s.fetchReplicationSlotsQuery(replicationSlotsQuery96)
+	default:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	}
+}
+
+const replicationSlotsQuery14 = `SELECT
+	s.slot_name,
+	s.plugin,
+	s.slot_type,
+	s.datoid,
+	s.database,
+	s.temporary,
+	s.active,
+	s.active_pid,
+	s.xmin,
+	s.catalog_xmin,
+	(s.restart_lsn - '0/0')::bigint,
+	(s.confirmed_flush_lsn - '0/0')::bigint,
+	s.wal_status,
+	s.safe_wal_size,
+	r.spill_txns,
+	r.spill_count,
+	r.spill_bytes,
+	r.stream_txns,
+	r.stream_count,
+	r.stream_bytes,
+	r.total_txns,
+	r.total_bytes,
+	r.stats_reset,
+	(CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END - s.restart_lsn)::bigint
+	FROM pg_replication_slots s
+	LEFT JOIN pg_stat_replication_slots r ON r.slot_name = s.slot_name`
+
+const replicationSlotsQuery13 = `SELECT
+	slot_name,
+	plugin,
+	slot_type,
+	datoid,
+	database,
+	temporary,
+	active,
+	active_pid,
+	xmin,
+	catalog_xmin,
+	(restart_lsn - '0/0')::bigint,
+	(confirmed_flush_lsn - '0/0')::bigint,
+	wal_status,
+	safe_wal_size,
+	NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL,
+	(CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END - restart_lsn)::bigint
+	FROM pg_replication_slots`
+
+const replicationSlotsQuery10 = `SELECT
+	slot_name,
+	plugin,
+	slot_type,
+	datoid,
+	database,
+	temporary,
+	active,
+	active_pid,
+	xmin,
+	catalog_xmin,
+	(restart_lsn - '0/0')::bigint,
+	(confirmed_flush_lsn - '0/0')::bigint,
+	NULL, NULL,
+	NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL,
+	(CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END - restart_lsn)::bigint
+	FROM pg_replication_slots`
+
+const replicationSlotsQuery96 = `SELECT
+	slot_name,
+	plugin,
+	slot_type,
+	datoid,
+	database,
+	NULL,
+	active,
+	active_pid,
+	xmin,
+	catalog_xmin,
+	(restart_lsn - '0/0')::bigint,
+	(confirmed_flush_lsn - '0/0')::bigint,
+	NULL, NULL,
+	NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL,
+	(CASE WHEN pg_is_in_recovery() THEN pg_last_xlog_receive_location() ELSE pg_current_xlog_location() END - restart_lsn)::bigint
+	FROM pg_replication_slots`
+
+func (s *Stats) fetchReplicationSlotsQuery(query string) ([]ReplicationSlotsRow, error) {
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []ReplicationSlotsRow{}
+	for rows.Next() {
+		var row ReplicationSlotsRow
+
+		err := rows.Scan(
+			&row.SlotName,
+			&row.Plugin,
+			&row.SlotType,
+			&row.Datoid,
+			&row.Database,
+			&row.Temporary,
+			&row.Active,
+			&row.ActivePid,
+			&row.Xmin,
+			&row.CatalogXmin,
+			&row.RestartLsn,
+			&row.ConfirmedFlushLsn,
+			&row.WalStatus,
+			&row.SafeWalSize,
+			&row.SpillTxns,
+			&row.SpillCount,
+			&row.SpillBytes,
+			&row.StreamTxns,
+			&row.StreamCount,
+			&row.StreamBytes,
+			&row.TotalTxns,
+			&row.TotalBytes,
+			&row.StatsReset,
+			&row.RetainedWalBytes,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
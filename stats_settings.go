@@ -0,0 +1,233 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settings returns rows from a `pg_settings` view with values converted according to their types.
+//
+// See: https://www.postgresql.org/docs/current/view-pg-settings.html
+func (s *Stats) Settings(ctx context.Context) ([]SettingsRow, error) {
+	return s.fetchSettings(ctx)
+}
+
+// SettingsRow represents schema of pg_settings view
+type SettingsRow struct {
+	Name           string           `json:"name"`            // Run-time configuration parameter name
+	Setting        string           `json:"setting"`         // Current value of the parameter
+	Unit           *sql.NullString  `json:"unit"`            // Implicit unit of the parameter
+	Category       string           `json:"category"`        // Logical group of the parameter
+	ShortDesc      string           `json:"short_desc"`      // A brief description of the parameter
+	Context        string           `json:"context"`         // Context required to set the parameter's value
+	Vartype        string           `json:"vartype"`         // Parameter type (bool, enum, integer, real, or string)
+	Source         string           `json:"source"`          // Source of the current parameter value
+	MinVal         *sql.NullString  `json:"min_val"`         // Minimum allowed value of the parameter (null for non-numeric values)
+	MaxVal         *sql.NullString  `json:"max_val"`         // Maximum allowed value of the parameter (null for non-numeric values)
+	Enumvals       []string         `json:"enumvals"`        // Allowed values in an enum parameter (null for non-enum values)
+	BootVal        *sql.NullString  `json:"boot_val"`        // Parameter value assumed at server startup if the parameter is not otherwise set
+	ResetVal       *sql.NullString  `json:"reset_val"`       // Value that RESET would reset the parameter to in the current session
+	PendingRestart bool             `json:"pending_restart"` // True if the value has been changed in the configuration file but needs a restart. Supported since PostgreSQL 9.5.
+	Bool           *sql.NullBool    `json:"bool"`            // Value of a bool parameter
+	Int            *sql.NullInt64   `json:"int"`             // Value of an integer parameter, in Unit
+	Real           *sql.NullFloat64 `json:"real"`            // Value of a real parameter, in Unit
+	String         *sql.NullString  `json:"string"`          // Value of an enum or string parameter
+	Bytes          *sql.NullInt64   `json:"bytes"`           // Value of a memory parameter in bytes, null for negative (special) values
+	Duration       *sql.NullInt64   `json:"duration"`        // Value of a time parameter in nanoseconds as time.Duration, null for negative (special) values
+}
+
+// SettingsChange represents a parameter which differs between two Settings reads.
+type SettingsChange struct {
+	Name string       `json:"name"` // Run-time configuration parameter name
+	Old  *SettingsRow `json:"old"`  // Parameter in the first read, nil if it was added
+	New  *SettingsRow `json:"new"`  // Parameter in the second read, nil if it was removed
+}
+
+// DiffSettings returns parameters whose value, source or pending restart flag differ between prev and curr reads.
+// Parameters present in only one of the reads are reported too, e.g. custom options of extensions.
+func DiffSettings(prev, curr []SettingsRow) []SettingsChange {
+	before := make(map[string]*SettingsRow, len(prev))
+	for i := range prev {
+		before[prev[i].Name] = &prev[i]
+	}
+
+	changes := []SettingsChange{}
+	for i := range curr {
+		c := &curr[i]
+		p, ok := before[c.Name]
+		delete(before, c.Name)
+
+		switch {
+		case !ok:
+			changes = append(changes, SettingsChange{Name: c.Name, New: c})
+		case p.Setting != c.Setting || p.Source != c.Source || p.PendingRestart != c.PendingRestart:
+			changes = append(changes, SettingsChange{Name: c.Name, Old: p, New: c})
+		}
+	}
+	for i := range prev {
+		if p, ok := before[prev[i].Name]; ok {
+			changes = append(changes, SettingsChange{Name: p.Name, Old: p})
+		}
+	}
+	return changes
+}
+
+func (s *Stats) fetchSettings(ctx context.Context) ([]SettingsRow, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingRestart := "pending_restart"
+	if version < 9.5 {
+		pendingRestart = "false"
+	}
+
+	const query = `SELECT
+	name,
+	setting,
+	unit,
+	category,
+	short_desc,
+	context,
+	vartype,
+	source,
+	min_val,
+	max_val,
+	array_to_string(enumvals, ','),
+	boot_val,
+	reset_val,
+	%s
+	FROM pg_settings
+	ORDER BY name`
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(query, pendingRestart))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []SettingsRow{}
+	for rows.Next() {
+		var row SettingsRow
+		var enumvals sql.NullString
+
+		err := rows.Scan(
+			&row.Name,
+			&row.Setting,
+			&row.Unit,
+			&row.Category,
+			&row.ShortDesc,
+			&row.Context,
+			&row.Vartype,
+			&row.Source,
+			&row.MinVal,
+			&row.MaxVal,
+			&enumvals,
+			&row.BootVal,
+			&row.ResetVal,
+			&row.PendingRestart,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if enumvals.Valid {
+			row.Enumvals = strings.Split(enumvals.String, ",")
+		}
+		row.parseValue()
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+// parseValue fills typed values of the row from Setting.
+func (r *SettingsRow) parseValue() {
+	r.Bool, r.Int, r.Real, r.String = &sql.NullBool{}, &sql.NullInt64{}, &sql.NullFloat64{}, &sql.NullString{}
+	r.Bytes, r.Duration = &sql.NullInt64{}, &sql.NullInt64{}
+
+	var value float64
+	switch r.Vartype {
+	case "bool":
+		r.Bool.Valid = true
+		r.Bool.Bool = r.Setting == "on"
+		return
+	case "integer":
+		v, err := strconv.ParseInt(r.Setting, 10, 64)
+		if err != nil {
+			return
+		}
+		r.Int.Int64, r.Int.Valid = v, true
+		value = float64(v)
+	case "real":
+		v, err := strconv.ParseFloat(r.Setting, 64)
+		if err != nil {
+			return
+		}
+		r.Real.Float64, r.Real.Valid = v, true
+		value = v
+	case "enum", "string":
+		r.String.String, r.String.Valid = r.Setting, true
+		return
+	default:
+		return
+	}
+
+	if r.Unit == nil || !r.Unit.Valid || value < 0 {
+		return
+	}
+	bytes, duration := parseSettingUnit(r.Unit.String)
+	switch {
+	case bytes > 0:
+		r.Bytes.Int64, r.Bytes.Valid = int64(value*float64(bytes)), true
+	case duration > 0:
+		r.Duration.Int64, r.Duration.Valid = int64(time.Duration(value*float64(duration))), true
+	}
+}
+
+// parseSettingUnit returns size of the unit in bytes or its duration, e.g. 8kB or min.
+func parseSettingUnit(unit string) (int64, time.Duration) {
+	i := strings.IndexFunc(unit, func(r rune) bool { return r < '0' || r > '9' })
+	if i < 0 {
+		return 0, 0
+	}
+
+	mult := int64(1)
+	if i > 0 {
+		m, err := strconv.ParseInt(unit[:i], 10, 64)
+		if err != nil {
+			return 0, 0
+		}
+		mult = m
+	}
+
+	switch unit[i:] {
+	case "B":
+		return mult, 0
+	case "kB":
+		return mult << 10, 0
+	case "MB":
+		return mult << 20, 0
+	case "GB":
+		return mult << 30, 0
+	case "TB":
+		return mult << 40, 0
+	case "us":
+		return 0, time.Duration(mult) * time.Microsecond
+	case "ms":
+		return 0, time.Duration(mult) * time.Millisecond
+	case "s":
+		return 0, time.Duration(mult) * time.Second
+	case "min":
+		return 0, time.Duration(mult) * time.Minute
+	case "h":
+		return 0, time.Duration(mult) * time.Hour
+	case "d":
+		return 0, time.Duration(mult) * 24 * time.Hour
+	default:
+		return 0, 0
+	}
+}
@@ -0,0 +1,126 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+)
+
+// UserTablesWithSizes returns content of `pg_stat_user_tables` view extended with sizes of every table.
+// Sizes are null if sizes are disabled with WithoutSizes.
+//
+// See: https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-DBSIZE
+func (s *Stats) UserTablesWithSizes() ([]TableSizesRow, error) {
+	return s.fetchTableSizes("pg_stat_user_tables")
+}
+
+// UserIndexesWithSizes returns content of `pg_stat_user_indexes` view extended with sizes of every index.
+// Sizes are null if sizes are disabled with WithoutSizes.
+//
+// See: https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-DBSIZE
+func (s *Stats) UserIndexesWithSizes() ([]IndexSizesRow, error) {
+	return s.fetchIndexSizes("pg_stat_user_indexes")
+}
+
+// TableSizesRow represents schema of pg_stat_*_tables views with sizes of a table.
+type TableSizesRow struct {
+	TablesRow
+	HeapSize    *sql.NullInt64 `json:"heap_size"`    // Disk space used by the main fork of this table in bytes
+	ToastSize   *sql.NullInt64 `json:"toast_size"`   // Disk space used by the TOAST table of this table, including its index, in bytes
+	IndexesSize *sql.NullInt64 `json:"indexes_size"` // Disk space used by indexes attached to this table in bytes
+	TotalSize   *sql.NullInt64 `json:"total_size"`   // Total disk space used by this table, including all indexes and TOAST data, in bytes
+}
+
+// IndexSizesRow represents schema of pg_stat_*_indexes views with size of an index.
+type IndexSizesRow struct {
+	IndexesRow
+	Size *sql.NullInt64 `json:"size"` // Disk space used by this index in bytes
+}
+
+func (s *Stats) fetchTableSizes(view string) ([]TableSizesRow, error) {
+	tables, err := s.fetchTable(view)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT
+	t.relid,
+	CASE WHEN $1 THEN pg_relation_size(t.relid) END,
+	CASE WHEN $1 THEN coalesce(pg_total_relation_size(nullif(c.reltoastrelid, 0)), 0) END,
+	CASE WHEN $1 THEN pg_indexes_size(t.relid) END,
+	CASE WHEN $1 THEN pg_total_relation_size(t.relid) END
+	FROM pg_class c
+	JOIN `
+
+	rows, err := s.db.Query(query+view+` t ON t.relid = c.oid`, !s.noSizes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sizes := map[int64]TableSizesRow{}
+	for rows.Next() {
+		var relid int64
+		var row TableSizesRow
+
+		err := rows.Scan(
+			&relid,
+			&row.HeapSize,
+			&row.ToastSize,
+			&row.IndexesSize,
+			&row.TotalSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sizes[relid] = row
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	data := make([]TableSizesRow, 0, len(tables))
+	for _, table := range tables {
+		row := sizes[table.Relid]
+		row.TablesRow = table
+		data = append(data, row)
+	}
+	return data, nil
+}
+
+func (s *Stats) fetchIndexSizes(view string) ([]IndexSizesRow, error) {
+	indexes, err := s.fetchIndexes(context.Background(), view)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT indexrelid, CASE WHEN $1 THEN pg_relation_size(indexrelid) END FROM `
+
+	rows, err := s.db.Query(query+view, !s.noSizes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sizes := map[int64]*sql.NullInt64{}
+	for rows.Next() {
+		var indexrelid int64
+		var size *sql.NullInt64
+
+		if err := rows.Scan(&indexrelid, &size); err != nil {
+			return nil, err
+		}
+		sizes[indexrelid] = size
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	data := make([]IndexSizesRow, 0, len(indexes))
+	for _, index := range indexes {
+		data = append(data, IndexSizesRow{
+			IndexesRow: index,
+			Size:       sizes[index.Indexrelid],
+		})
+	}
+	return data, nil
+}
@@ -0,0 +1,79 @@
+package pgstats
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Slru returns rows from a `pg_stat_slru` view.
+// One row per SLRU (simple least-recently-used) cache, showing statistics of operations on it.
+//
+// See: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-SLRU-VIEW
+func (s *Stats) Slru() ([]SlruRow, error) {
+	return s.fetchSlru()
+}
+
+// SlruRow represents schema of pg_stat_slru view
+type SlruRow struct {
+	Name        string         `json:"name"`         // Name of the SLRU
+	BlksZeroed  *sql.NullInt64 `json:"blks_zeroed"`  // Number of blocks zeroed during initializations
+	BlksHit     *sql.NullInt64 `json:"blks_hit"`     // Number of times disk blocks were found already in the SLRU, so that a read was not necessary
+	BlksRead    *sql.NullInt64 `json:"blks_read"`    // Number of disk blocks read for this SLRU
+	BlksWritten *sql.NullInt64 `json:"blks_written"` // Number of disk blocks written for this SLRU
+	BlksExists  *sql.NullInt64 `json:"blks_exists"`  // Number of blocks checked for existence for this SLRU
+	Flushes     *sql.NullInt64 `json:"flushes"`      // Number of flushes of dirty data for this SLRU
+	Truncates   *sql.NullInt64 `json:"truncates"`    // Number of truncates for this SLRU
+	StatsReset  *sql.NullTime  `json:"stats_reset"`  // Time at which these statistics were last reset
+}
+
+func (s *Stats) fetchSlru() ([]SlruRow, error) {
+	version, err := s.getVersion()
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 13:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	default:
+		// pass
+	}
+
+	const query = `SELECT
+	name,
+	blks_zeroed,
+	blks_hit,
+	blks_read,
+	blks_written,
+	blks_exists,
+	flushes,
+	truncates,
+	stats_reset
+	FROM pg_stat_slru`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []SlruRow{}
+	for rows.Next() {
+		var row SlruRow
+
+		err := rows.Scan(
+			&row.Name,
+			&row.BlksZeroed,
+			&row.BlksHit,
+			&row.BlksRead,
+			&row.BlksWritten,
+			&row.BlksExists,
+			&row.Flushes,
+			&row.Truncates,
+			&row.StatsReset,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
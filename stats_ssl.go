@@ -1,6 +1,7 @@
 package pgstats
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 )
@@ -9,40 +10,80 @@ import (
 //
 // See: https://www.postgresql.org/docs/current/monitoring-stats.html#PG-STAT-SSL
 func (s *Stats) Ssl() ([]SslRow, error) {
-	return s.fetchSsl()
+	return s.fetchSsl(context.Background())
 }
 
 // SslRow represents schema of pg_stat_ssl view.
 type SslRow struct {
-	Pid         int64           `json:"pid"`         // Process ID of a backend or WAL sender process
-	Ssl         bool            `json:"ssl"`         // True if SSL is used on this connection
-	Version     *sql.NullString `json:"version"`     // Version of SSL in use, or NULL if SSL is not in use on this connection
-	Cipher      *sql.NullString `json:"cipher"`      // Name of SSL cipher in use, or NULL if SSL is not in use on this connection
-	Bits        *sql.NullInt64  `json:"bits"`        // Number of bits in the encryption algorithm used, or NULL if SSL is not used on this connection
-	Compression *sql.NullBool   `json:"compression"` // True if SSL compression is in use, false if not, or NULL if SSL is not in use on this connection
-	Clientdn    *sql.NullString `json:"clientdn"`    // Distinguished Name (DN) field from the client certificate used.
+	Pid          int64           `json:"pid"`           // Process ID of a backend or WAL sender process
+	Ssl          bool            `json:"ssl"`           // True if SSL is used on this connection
+	Version      *sql.NullString `json:"version"`       // Version of SSL in use, or NULL if SSL is not in use on this connection
+	Cipher       *sql.NullString `json:"cipher"`        // Name of SSL cipher in use, or NULL if SSL is not in use on this connection
+	Bits         *sql.NullInt64  `json:"bits"`          // Number of bits in the encryption algorithm used, or NULL if SSL is not used on this connection
+	Compression  *sql.NullBool   `json:"compression"`   // True if SSL compression is in use, false if not, or NULL if SSL is not in use on this connection. Supported until PostgreSQL 13 (inclusive).
+	Clientdn     *sql.NullString `json:"clientdn"`      // Deprecated: use ClientDn, both are filled on every version.
+	ClientDn     *sql.NullString `json:"client_dn"`     // Distinguished Name (DN) field from the client certificate used.
+	ClientSerial *sql.NullString `json:"client_serial"` // Serial number of the client certificate. Supported since PostgreSQL 12.
+	IssuerDn     *sql.NullString `json:"issuer_dn"`     // DN of the issuer of the client certificate. Supported since PostgreSQL 12.
 }
 
-func (s *Stats) fetchSsl() ([]SslRow, error) {
-	version, err := s.getVersion()
+func (s *Stats) fetchSsl(ctx context.Context) ([]SslRow, error) {
+	version, err := s.getVersionContext(ctx)
 	switch {
 	case err != nil:
 		return nil, err
-	case version < 9.5:
+	case version >= 14:
+		return s.fetchSslQuery(ctx, sslQuery14)
+	case version >= 12:
+		return s.fetchSslQuery(ctx, sslQuery12)
+	case version >= 9.5:
+		return s.fetchSslQuery(ctx, sslQuery95)
+	default:
 		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
 	}
+}
+
+const sslQuery14 = `SELECT
+	pid,
+	ssl,
+	version,
+	cipher,
+	bits,
+	NULL,
+	client_dn,
+	client_dn,
+	client_serial::text,
+	issuer_dn
+	FROM pg_stat_ssl`
+
+const sslQuery12 = `SELECT
+	pid,
+	ssl,
+	version,
+	cipher,
+	bits,
+	compression,
+	client_dn,
+	client_dn,
+	client_serial::text,
+	issuer_dn
+	FROM pg_stat_ssl`
 
-	const query = `SELECT
+const sslQuery95 = `SELECT
 	pid,
 	ssl,
 	version,
 	cipher,
 	bits,
 	compression,
-	clientdn
+	clientdn,
+	clientdn,
+	NULL,
+	NULL
 	FROM pg_stat_ssl`
 
-	rows, err := s.db.Query(query)
+func (s *Stats) fetchSslQuery(ctx context.Context, query string) ([]SslRow, error) {
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +101,9 @@ func (s *Stats) fetchSsl() ([]SslRow, error) {
 			&row.Bits,
 			&row.Compression,
 			&row.Clientdn,
+			&row.ClientDn,
+			&row.ClientSerial,
+			&row.IssuerDn,
 		)
 		if err != nil {
 			return nil, err
@@ -1,5 +1,10 @@
 package pgstats
 
+import (
+	"context"
+	"database/sql"
+)
+
 // Statements returns rows from a `pg_stat_statements` view.
 // The pg_stat_statements module provides a means for tracking execution statistics of all SQL statements executed by a server.
 //
@@ -10,45 +15,153 @@ func (s *Stats) Statements() ([]StatementsRow, error) {
 
 // StatementsRow represents rows of pg_stat_statements view.
 type StatementsRow struct {
-	Userid            int64   `json:"userid"`              // OID of user who executed the statement
-	Dbid              int64   `json:"dbid"`                // OID of database in which the statement was executed
-	Queryid           int64   `json:"queryid"`             // Internal hash code, computed from the statement's parse tree
-	Query             string  `json:"query"`               // Text of a representative statement
-	Calls             int64   `json:"calls"`               // Number of times executed
-	TotalTime         float64 `json:"total_time"`          // Total time spent in the statement, in milliseconds.
-	MinTime           float64 `json:"min_time"`            // Minimum time spent in the statement, in milliseconds.
-	MaxTime           float64 `json:"max_time"`            // Maximum time spent in the statement, in milliseconds.
-	MeanTime          float64 `json:"mean_time"`           // Mean time spent in the statement, in milliseconds.
-	StddevTime        float64 `json:"stddev_time"`         // Population standard deviation of time spent in the statement, in milliseconds.
-	Rows              int64   `json:"rows"`                // Total number of rows retrieved or affected by the statement
-	SharedBlksHit     int64   `json:"shared_blks_hit"`     // Total number of shared block cache hits by the statement
-	SharedBlksRead    int64   `json:"shared_blks_read"`    // Total number of shared blocks read by the statement
-	SharedBlksDirtied int64   `json:"shared_blks_dirtied"` // Total number of shared blocks dirtied by the statement
-	SharedBlksWritten int64   `json:"shared_blks_written"` // Total number of shared blocks written by the statement
-	LocalBlksHit      int64   `json:"local_blks_hit"`      // Total number of local block cache hits by the statement
-	LocalBlksRead     int64   `json:"local_blks_read"`     // Total number of local blocks read by the statement
-	LocalBlksDirtied  int64   `json:"local_blks_dirtied"`  // Total number of local blocks dirtied by the statement
-	LocalBlksWritten  int64   `json:"local_blks_written"`  // Total number of local blocks written by the statement
-	TempBlksRead      int64   `json:"temp_blks_read"`      // Total number of temp blocks read by the statement
-	TempBlksWritten   int64   `json:"temp_blks_written"`   // Total number of temp blocks written by the statement
-	BlkReadTime       float64 `json:"blk_read_time"`       // Total time the statement spent reading blocks, in milliseconds (if track_io_timing is enabled, otherwise zero)
-	BlkWriteTime      float64 `json:"blk_write_time"`      // Total time the statement spent writing blocks, in milliseconds (if track_io_timing is enabled, otherwise zero)
+	Userid            int64         `json:"userid"`              // OID of user who executed the statement
+	Dbid              int64         `json:"dbid"`                // OID of database in which the statement was executed
+	Queryid           int64         `json:"queryid"`             // Internal hash code, computed from the statement's parse tree
+	Query             string        `json:"query"`               // Text of a representative statement
+	Calls             int64         `json:"calls"`               // Number of times executed
+	TotalTime         float64       `json:"total_time"`          // Total time spent in the statement, in milliseconds.
+	MinTime           float64       `json:"min_time"`            // Minimum time spent in the statement, in milliseconds.
+	MaxTime           float64       `json:"max_time"`            // Maximum time spent in the statement, in milliseconds.
+	MeanTime          float64       `json:"mean_time"`           // Mean time spent in the statement, in milliseconds.
+	StddevTime        float64       `json:"stddev_time"`         // Population standard deviation of time spent in the statement, in milliseconds.
+	Rows              int64         `json:"rows"`                // Total number of rows retrieved or affected by the statement
+	SharedBlksHit     int64         `json:"shared_blks_hit"`     // Total number of shared block cache hits by the statement
+	SharedBlksRead    int64         `json:"shared_blks_read"`    // Total number of shared blocks read by the statement
+	SharedBlksDirtied int64         `json:"shared_blks_dirtied"` // Total number of shared blocks dirtied by the statement
+	SharedBlksWritten int64         `json:"shared_blks_written"` // Total number of shared blocks written by the statement
+	LocalBlksHit      int64         `json:"local_blks_hit"`      // Total number of local block cache hits by the statement
+	LocalBlksRead     int64         `json:"local_blks_read"`     // Total number of local blocks read by the statement
+	LocalBlksDirtied  int64         `json:"local_blks_dirtied"`  // Total number of local blocks dirtied by the statement
+	LocalBlksWritten  int64         `json:"local_blks_written"`  // Total number of local blocks written by the statement
+	TempBlksRead      int64         `json:"temp_blks_read"`      // Total number of temp blocks read by the statement
+	TempBlksWritten   int64         `json:"temp_blks_written"`   // Total number of temp blocks written by the statement
+	BlkReadTime       float64       `json:"blk_read_time"`       // Total time the statement spent reading blocks, in milliseconds (if track_io_timing is enabled, otherwise zero)
+	BlkWriteTime      float64       `json:"blk_write_time"`      // Total time the statement spent writing blocks, in milliseconds (if track_io_timing is enabled, otherwise zero)
+	Toplevel          *sql.NullBool `json:"toplevel"`            // True if the query was executed as a top-level statement. Supported since PostgreSQL 14.
 }
 
+// fetchStatements chooses columns by the version of pg_stat_statements installed in the current database,
+// which may be older than the one shipped with the server. If it is not installed, the server version is used.
 func (s *Stats) fetchStatements() ([]StatementsRow, error) {
+	ext, err := s.getExtensionVersionContext(context.Background(), "pg_stat_statements")
+	switch {
+	case err != nil:
+		return nil, err
+	case ext >= 111:
+		return s.fetchStatementsQuery(statementsQuery17)
+	case ext >= 109:
+		return s.fetchStatementsQuery(statementsQuery14)
+	case ext >= 108:
+		return s.fetchStatementsQuery(statementsQuery13)
+	case ext >= 103:
+		return s.fetchStatementsQuery(statementsQuery95)
+	case ext > 0:
+		return s.fetchStatements94()
+	}
+
 	version, err := s.getVersion()
 	switch {
 	case err != nil:
 		return nil, err
+	case version >= 17:
+		return s.fetchStatementsQuery(statementsQuery17)
+	case version >= 14:
+		return s.fetchStatementsQuery(statementsQuery14)
+	case version >= 13:
+		return s.fetchStatementsQuery(statementsQuery13)
 	case version > 9.4:
-		return s.fetchStatements95()
+		return s.fetchStatementsQuery(statementsQuery95)
 	default:
 		return s.fetchStatements94()
 	}
 }
 
-func (s *Stats) fetchStatements95() ([]StatementsRow, error) {
-	const query = `SELECT
+// statementsQuery17 sums shared and local block IO times which are split since pg_stat_statements 1.11 (PostgreSQL 17).
+const statementsQuery17 = `SELECT
+	userid,
+	dbid,
+	queryid,
+	query,
+	calls,
+	total_exec_time,
+	min_exec_time,
+	max_exec_time,
+	mean_exec_time,
+	stddev_exec_time,
+	rows,
+	shared_blks_hit,
+	shared_blks_read,
+	shared_blks_dirtied,
+	shared_blks_written,
+	local_blks_hit,
+	local_blks_read,
+	local_blks_dirtied,
+	local_blks_written,
+	temp_blks_read,
+	temp_blks_written,
+	shared_blk_read_time + local_blk_read_time,
+	shared_blk_write_time + local_blk_write_time,
+	toplevel
+	FROM pg_stat_statements`
+
+// statementsQuery14 reads toplevel, pg_stat_statements 1.9 (PostgreSQL 14) tracks nested statements separately.
+const statementsQuery14 = `SELECT
+	userid,
+	dbid,
+	queryid,
+	query,
+	calls,
+	total_exec_time,
+	min_exec_time,
+	max_exec_time,
+	mean_exec_time,
+	stddev_exec_time,
+	rows,
+	shared_blks_hit,
+	shared_blks_read,
+	shared_blks_dirtied,
+	shared_blks_written,
+	local_blks_hit,
+	local_blks_read,
+	local_blks_dirtied,
+	local_blks_written,
+	temp_blks_read,
+	temp_blks_written,
+	blk_read_time,
+	blk_write_time,
+	toplevel
+	FROM pg_stat_statements`
+
+// statementsQuery13 reads execution times, *_time columns are renamed to *_exec_time since pg_stat_statements 1.8 (PostgreSQL 13).
+const statementsQuery13 = `SELECT
+	userid,
+	dbid,
+	queryid,
+	query,
+	calls,
+	total_exec_time,
+	min_exec_time,
+	max_exec_time,
+	mean_exec_time,
+	stddev_exec_time,
+	rows,
+	shared_blks_hit,
+	shared_blks_read,
+	shared_blks_dirtied,
+	shared_blks_written,
+	local_blks_hit,
+	local_blks_read,
+	local_blks_dirtied,
+	local_blks_written,
+	temp_blks_read,
+	temp_blks_written,
+	blk_read_time,
+	blk_write_time,
+	NULL
+	FROM pg_stat_statements`
+
+const statementsQuery95 = `SELECT
 	userid,
 	dbid,
 	queryid,
@@ -71,9 +184,11 @@ func (s *Stats) fetchStatements95() ([]StatementsRow, error) {
 	temp_blks_read,
 	temp_blks_written,
 	blk_read_time,
-	blk_write_time
+	blk_write_time,
+	NULL
 	FROM pg_stat_statements`
 
+func (s *Stats) fetchStatementsQuery(query string) ([]StatementsRow, error) {
 	rows, err := s.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -108,6 +223,7 @@ func (s *Stats) fetchStatements95() ([]StatementsRow, error) {
 			&row.TempBlksWritten,
 			&row.BlkReadTime,
 			&row.BlkWriteTime,
+			&row.Toplevel,
 		)
 		if err != nil {
 			return nil, err
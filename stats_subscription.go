@@ -19,11 +19,13 @@ type SubscriptionRow struct {
 	Subname            *sql.NullString `json:"subname"`               // Name of the subscription
 	Pid                *sql.NullInt64  `json:"pid"`                   // Process ID of the subscription worker process
 	Relid              *sql.NullInt64  `json:"relid"`                 // OID of the relation that the worker is synchronizing; null for the main apply worker
-	ReceivedLsn        *sql.NullInt64  `json:"received_lsn"`          // Last write-ahead log location received, the initial value of this field being 0
+	ReceivedLsn        *sql.NullInt64  `json:"received_lsn"`          // Last write-ahead log location received, as a byte position
 	LastMsgSendTime    *sql.NullTime   `json:"last_msg_send_time"`    // Send time of last message received from origin WAL sender
 	LastMsgReceiptTime *sql.NullTime   `json:"last_msg_receipt_time"` // Receipt time of last message received from origin WAL sender
-	LatestEndLsn       *sql.NullInt64  `json:"latest_end_lsn"`        // Last write-ahead log location reported to origin WAL sender
+	LatestEndLsn       *sql.NullInt64  `json:"latest_end_lsn"`        // Last write-ahead log location reported to origin WAL sender, as a byte position
 	LatestEndTime      *sql.NullTime   `json:"latest_end_time"`       // Time of last write-ahead log location reported to origin WAL sender
+	LeaderPid          *sql.NullInt64  `json:"leader_pid"`            // Process ID of the leader apply worker if this process is a parallel apply worker. Supported since PostgreSQL 16.
+	WorkerType         *sql.NullString `json:"worker_type"`           // Type of the subscription worker process: apply, parallel apply or table synchronization. Supported since PostgreSQL 17.
 }
 
 func (s *Stats) fetchSubscription() ([]SubscriptionRow, error) {
@@ -31,24 +33,60 @@ func (s *Stats) fetchSubscription() ([]SubscriptionRow, error) {
 	switch {
 	case err != nil:
 		return nil, err
-	case version < 10:
-		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	case version >= 17:
+		return s.fetchSubscriptionQuery(subscriptionQuery17)
+	case version >= 16:
+		return s.fetchSubscriptionQuery(subscriptionQuery16)
+	case version >= 10:
+		return s.fetchSubscriptionQuery(subscriptionQuery10)
 	default:
-		//pass
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
 	}
+}
+
+const subscriptionQuery17 = `SELECT
+	subid,
+	subname,
+	pid,
+	relid,
+	(received_lsn - '0/0')::bigint,
+	last_msg_send_time,
+	last_msg_receipt_time,
+	(latest_end_lsn - '0/0')::bigint,
+	latest_end_time,
+	leader_pid,
+	worker_type
+	FROM pg_stat_subscription`
+
+const subscriptionQuery16 = `SELECT
+	subid,
+	subname,
+	pid,
+	relid,
+	(received_lsn - '0/0')::bigint,
+	last_msg_send_time,
+	last_msg_receipt_time,
+	(latest_end_lsn - '0/0')::bigint,
+	latest_end_time,
+	leader_pid,
+	NULL
+	FROM pg_stat_subscription`
 
-	const query = `SELECT
+const subscriptionQuery10 = `SELECT
 	subid,
 	subname,
 	pid,
 	relid,
-	received_lsn,
+	(received_lsn - '0/0')::bigint,
 	last_msg_send_time,
 	last_msg_receipt_time,
-	latest_end_lsn,
-	latest_end_time 
+	(latest_end_lsn - '0/0')::bigint,
+	latest_end_time,
+	NULL,
+	NULL
 	FROM pg_stat_subscription`
 
+func (s *Stats) fetchSubscriptionQuery(query string) ([]SubscriptionRow, error) {
 	rows, err := s.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -69,6 +107,8 @@ func (s *Stats) fetchSubscription() ([]SubscriptionRow, error) {
 			&row.LastMsgReceiptTime,
 			&row.LatestEndLsn,
 			&row.LatestEndTime,
+			&row.LeaderPid,
+			&row.WorkerType,
 		)
 		if err != nil {
 			return nil, err
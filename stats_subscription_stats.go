@@ -0,0 +1,170 @@
+package pgstats
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SubscriptionStats represents content of `pg_stat_subscription_stats` view.
+// One row per subscription, showing statistics about errors and conflicts. Requires PostgreSQL 15+.
+//
+// See: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-SUBSCRIPTION-STATS
+func (s *Stats) SubscriptionStats() ([]SubscriptionStatsRow, error) {
+	return s.fetchSubscriptionStats()
+}
+
+// SubscriptionStatsRow represents schema of pg_stat_subscription_stats view.
+type SubscriptionStatsRow struct {
+	Subid                        int64          `json:"subid"`                           // OID of the subscription
+	Subname                      string         `json:"subname"`                         // Name of the subscription
+	ApplyErrorCount              *sql.NullInt64 `json:"apply_error_count"`               // Number of times an error occurred while applying changes
+	SyncErrorCount               *sql.NullInt64 `json:"sync_error_count"`                // Number of times an error occurred during the initial table synchronization
+	ConflInsertExists            *sql.NullInt64 `json:"confl_insert_exists"`             // Number of times a row insertion violated a NOT DEFERRABLE unique constraint. Supported since PostgreSQL 18.
+	ConflUpdateOriginDiffers     *sql.NullInt64 `json:"confl_update_origin_differs"`     // Number of times an update was applied to a row that had been previously modified by another source. Supported since PostgreSQL 18.
+	ConflUpdateExists            *sql.NullInt64 `json:"confl_update_exists"`             // Number of times that an updated row value violated a NOT DEFERRABLE unique constraint. Supported since PostgreSQL 18.
+	ConflUpdateMissing           *sql.NullInt64 `json:"confl_update_missing"`            // Number of times the tuple to be updated was not found. Supported since PostgreSQL 18.
+	ConflDeleteOriginDiffers     *sql.NullInt64 `json:"confl_delete_origin_differs"`     // Number of times a delete operation was applied to row that had been previously modified by another source. Supported since PostgreSQL 18.
+	ConflDeleteMissing           *sql.NullInt64 `json:"confl_delete_missing"`            // Number of times the tuple to be deleted was not found. Supported since PostgreSQL 18.
+	ConflMultipleUniqueConflicts *sql.NullInt64 `json:"confl_multiple_unique_conflicts"` // Number of times a row insertion or an updated row values violated multiple NOT DEFERRABLE unique constraints. Supported since PostgreSQL 18.
+	StatsReset                   *sql.NullTime  `json:"stats_reset"`                     // Time at which these statistics were last reset
+}
+
+func (s *Stats) fetchSubscriptionStats() ([]SubscriptionStatsRow, error) {
+	version, err := s.getVersion()
+	switch {
+	case err != nil:
+		return nil, err
+	case version >= 18:
+		return s.fetchSubscriptionStatsQuery(subscriptionStatsQuery18)
+	case version >= 15:
+		return s.fetchSubscriptionStatsQuery(subscriptionStatsQuery15)
+	default:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	}
+}
+
+const subscriptionStatsQuery18 = `SELECT
+	subid,
+	subname,
+	apply_error_count,
+	sync_error_count,
+	confl_insert_exists,
+	confl_update_origin_differs,
+	confl_update_exists,
+	confl_update_missing,
+	confl_delete_origin_differs,
+	confl_delete_missing,
+	confl_multiple_unique_conflicts,
+	stats_reset
+	FROM pg_stat_subscription_stats`
+
+const subscriptionStatsQuery15 = `SELECT
+	subid,
+	subname,
+	apply_error_count,
+	sync_error_count,
+	NULL, NULL, NULL, NULL, NULL, NULL, NULL,
+	stats_reset
+	FROM pg_stat_subscription_stats`
+
+func (s *Stats) fetchSubscriptionStatsQuery(query string) ([]SubscriptionStatsRow, error) {
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []SubscriptionStatsRow{}
+	for rows.Next() {
+		var row SubscriptionStatsRow
+
+		err := rows.Scan(
+			&row.Subid,
+			&row.Subname,
+			&row.ApplyErrorCount,
+			&row.SyncErrorCount,
+			&row.ConflInsertExists,
+			&row.ConflUpdateOriginDiffers,
+			&row.ConflUpdateExists,
+			&row.ConflUpdateMissing,
+			&row.ConflDeleteOriginDiffers,
+			&row.ConflDeleteMissing,
+			&row.ConflMultipleUniqueConflicts,
+			&row.StatsReset,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+// SubscriptionRel represents content of `pg_subscription_rel` catalog joined with subscription and table names.
+// One row per table of each subscription in the current database, showing its synchronization state.
+//
+// See: https://www.postgresql.org/docs/current/catalog-pg-subscription-rel.html
+func (s *Stats) SubscriptionRel() ([]SubscriptionRelRow, error) {
+	return s.fetchSubscriptionRel()
+}
+
+// SubscriptionRelRow represents schema of pg_subscription_rel catalog.
+type SubscriptionRelRow struct {
+	Srsubid    int64          `json:"srsubid"`    // OID of the subscription
+	Subname    string         `json:"subname"`    // Name of the subscription
+	Srrelid    int64          `json:"srrelid"`    // OID of the relation
+	Schemaname string         `json:"schemaname"` // Name of the schema that the relation is in
+	Relname    string         `json:"relname"`    // Name of the relation
+	Srsubstate string         `json:"srsubstate"` // State code: i = initialize, d = data is being copied, f = finished table copy, s = synchronized, r = ready (normal replication)
+	Srsublsn   *sql.NullInt64 `json:"srsublsn"`   // Remote LSN of the state change used for synchronization coordination when in s or r states, as a byte position
+}
+
+func (s *Stats) fetchSubscriptionRel() ([]SubscriptionRelRow, error) {
+	version, err := s.getVersion()
+	switch {
+	case err != nil:
+		return nil, err
+	case version < 10:
+		return nil, fmt.Errorf("Unsupported PostgreSQL version: %f", version)
+	}
+
+	const query = `SELECT
+	r.srsubid,
+	s.subname,
+	r.srrelid,
+	n.nspname,
+	c.relname,
+	r.srsubstate,
+	(r.srsublsn - '0/0')::bigint
+	FROM pg_subscription_rel r
+	JOIN pg_subscription s ON s.oid = r.srsubid
+	JOIN pg_class c ON c.oid = r.srrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	ORDER BY s.subname, n.nspname, c.relname`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []SubscriptionRelRow{}
+	for rows.Next() {
+		var row SubscriptionRelRow
+
+		err := rows.Scan(
+			&row.Srsubid,
+			&row.Subname,
+			&row.Srrelid,
+			&row.Schemaname,
+			&row.Relname,
+			&row.Srsubstate,
+			&row.Srsublsn,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
@@ -1,6 +1,9 @@
 package pgstats
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+)
 
 // AllTables represents content of `pg_stat_all_tables` view.
 // AllTables returns a slice containing statistics about accesses
@@ -49,9 +52,58 @@ type TablesRow struct {
 	AutovacuumCount  *sql.NullInt64 `json:"autovacuum_count"`    // Number of times this table has been vacuumed by the autovacuum daemon
 	AnalyzeCount     *sql.NullInt64 `json:"analyze_count"`       // Number of times this table has been manually analyzed
 	AutoanalyzeCount *sql.NullInt64 `json:"autoanalyze_count"`   // Number of times this table has been analyzed by the autovacuum daemon
+
+	NInsSinceVacuum      *sql.NullInt64   `json:"n_ins_since_vacuum"`     // Estimated number of rows inserted since this table was last vacuumed. Supported since PostgreSQL 13.
+	LastSeqScan          *sql.NullTime    `json:"last_seq_scan"`          // The time of the last sequential scan on this table. Supported since PostgreSQL 16.
+	LastIdxScan          *sql.NullTime    `json:"last_idx_scan"`          // The time of the last index scan on this table. Supported since PostgreSQL 16.
+	NTupNewpageUpd       *sql.NullInt64   `json:"n_tup_newpage_upd"`      // Number of rows updated where the successor version goes onto a new heap page. Supported since PostgreSQL 16.
+	TotalVacuumTime      *sql.NullFloat64 `json:"total_vacuum_time"`      // Total time this table has been manually vacuumed, in milliseconds. Supported since PostgreSQL 18.
+	TotalAutovacuumTime  *sql.NullFloat64 `json:"total_autovacuum_time"`  // Total time this table has been vacuumed by the autovacuum daemon, in milliseconds. Supported since PostgreSQL 18.
+	TotalAnalyzeTime     *sql.NullFloat64 `json:"total_analyze_time"`     // Total time this table has been manually analyzed, in milliseconds. Supported since PostgreSQL 18.
+	TotalAutoanalyzeTime *sql.NullFloat64 `json:"total_autoanalyze_time"` // Total time this table has been analyzed by the autovacuum daemon, in milliseconds. Supported since PostgreSQL 18.
 }
 
+const (
+	tablesColumns18 = `n_ins_since_vacuum,
+	last_seq_scan,
+	last_idx_scan,
+	n_tup_newpage_upd,
+	total_vacuum_time,
+	total_autovacuum_time,
+	total_analyze_time,
+	total_autoanalyze_time`
+
+	tablesColumns16 = `n_ins_since_vacuum,
+	last_seq_scan,
+	last_idx_scan,
+	n_tup_newpage_upd,
+	NULL, NULL, NULL, NULL`
+
+	tablesColumns13 = `n_ins_since_vacuum,
+	NULL, NULL, NULL,
+	NULL, NULL, NULL, NULL`
+
+	tablesColumns96 = `NULL,
+	NULL, NULL, NULL,
+	NULL, NULL, NULL, NULL`
+)
+
 func (s *Stats) fetchTable(view string) ([]TablesRow, error) {
+	version, err := s.getVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := tablesColumns96
+	switch {
+	case version >= 18:
+		columns = tablesColumns18
+	case version >= 16:
+		columns = tablesColumns16
+	case version >= 13:
+		columns = tablesColumns13
+	}
+
 	const query = `SELECT
 	relid,
 	schemaname,
@@ -74,10 +126,11 @@ func (s *Stats) fetchTable(view string) ([]TablesRow, error) {
 	vacuum_count,
 	autovacuum_count,
 	analyze_count,
-	autoanalyze_count
-	FROM `
+	autoanalyze_count,
+	%s
+	FROM %s`
 
-	rows, err := s.db.Query(query + view)
+	rows, err := s.db.Query(fmt.Sprintf(query, columns, view))
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +163,14 @@ func (s *Stats) fetchTable(view string) ([]TablesRow, error) {
 			&row.AutovacuumCount,
 			&row.AnalyzeCount,
 			&row.AutoanalyzeCount,
+			&row.NInsSinceVacuum,
+			&row.LastSeqScan,
+			&row.LastIdxScan,
+			&row.NTupNewpageUpd,
+			&row.TotalVacuumTime,
+			&row.TotalAutovacuumTime,
+			&row.TotalAnalyzeTime,
+			&row.TotalAutoanalyzeTime,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,235 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+)
+
+// wraparoundLimit is the distance in transactions to the transaction ID wraparound.
+const wraparoundLimit = 1 << 31
+
+// wraparoundTables is the default number of tables returned by Wraparound.
+const wraparoundTables = 20
+
+// Wraparound returns transaction ID and multixact ID ages of databases and tables,
+// together with the oldest xmin holders which prevent vacuum from freezing.
+// Databases and tables are ordered by the greater of FreezeMaxAgePercent and MxidFreezeMaxAgePercent,
+// only the top n tables of the current database are returned, a non-positive n is replaced with 20.
+//
+// See: https://www.postgresql.org/docs/current/routine-vacuuming.html#VACUUM-FOR-WRAPAROUND
+func (s *Stats) Wraparound(ctx context.Context, n int) (WraparoundView, error) {
+	if n <= 0 {
+		n = wraparoundTables
+	}
+	return s.fetchWraparound(ctx, n)
+}
+
+// WraparoundView represents wraparound state of the cluster.
+type WraparoundView struct {
+	FreezeMaxAge          int64                  `json:"autovacuum_freeze_max_age"`           // Value of autovacuum_freeze_max_age setting
+	MultixactFreezeMaxAge int64                  `json:"autovacuum_multixact_freeze_max_age"` // Value of autovacuum_multixact_freeze_max_age setting
+	Databases             []WraparoundRow        `json:"databases"`                           // Ages of every database
+	Tables                []WraparoundRow        `json:"tables"`                              // Ages of the oldest tables in the current database
+	XminHolders           []WraparoundXminHolder `json:"xmin_holders"`                        // Backends, prepared transactions and replication slots holding back the xmin horizon, oldest first
+}
+
+// WraparoundRow represents transaction ID and multixact ID ages of a database or a table.
+type WraparoundRow struct {
+	Oid                     int64          `json:"oid"`                         // OID of a database or a table
+	Schemaname              string         `json:"schemaname"`                  // Name of the schema that this table is in, empty for databases
+	Name                    string         `json:"name"`                        // Name of this database or table
+	XidAge                  int64          `json:"xid_age"`                     // Age of datfrozenxid or relfrozenxid
+	MxidAge                 *sql.NullInt64 `json:"mxid_age"`                    // Age of datminmxid or relminmxid. Supported since PostgreSQL 9.5.
+	FreezeMaxAgePercent     float64        `json:"freeze_max_age_percent"`      // Percent of autovacuum_freeze_max_age reached by XidAge
+	MxidFreezeMaxAgePercent float64        `json:"mxid_freeze_max_age_percent"` // Percent of autovacuum_multixact_freeze_max_age reached by MxidAge
+	WraparoundPercent       float64        `json:"wraparound_percent"`          // Percent of the 2^31 hard limit reached by the oldest of XidAge and MxidAge
+}
+
+// WraparoundXminHolder represents a backend, a prepared transaction or a replication slot holding back the xmin horizon.
+type WraparoundXminHolder struct {
+	Kind    string          `json:"kind"`    // One of: backend, prepared transaction, replication slot, replication slot catalog
+	Name    string          `json:"name"`    // Process ID of a backend, gid of a prepared transaction or name of a replication slot
+	Datname *sql.NullString `json:"datname"` // Name of the database of this holder
+	Usename *sql.NullString `json:"usename"` // Name of the user of this holder
+	Xmin    int64           `json:"xmin"`    // The oldest transaction that this holder needs the database to retain
+	XidAge  int64           `json:"xid_age"` // Age of Xmin
+	Since   *sql.NullTime   `json:"since"`   // Time when the transaction of a backend was started or the transaction was prepared
+}
+
+func (s *Stats) fetchWraparound(ctx context.Context, tables int) (WraparoundView, error) {
+	version, err := s.getVersionContext(ctx)
+	if err != nil {
+		return WraparoundView{}, err
+	}
+
+	settings, err := s.getSettingsContext(ctx, "autovacuum_freeze_max_age", "autovacuum_multixact_freeze_max_age")
+	if err != nil {
+		return WraparoundView{}, err
+	}
+
+	var res WraparoundView
+	res.FreezeMaxAge, _ = strconv.ParseInt(settings["autovacuum_freeze_max_age"], 10, 64)
+	res.MultixactFreezeMaxAge, _ = strconv.ParseInt(settings["autovacuum_multixact_freeze_max_age"], 10, 64)
+
+	databasesQuery, tablesQuery := wraparoundDatabasesQuery95, wraparoundTablesQuery95
+	args := []interface{}{res.FreezeMaxAge, res.MultixactFreezeMaxAge}
+	if version < 9.5 {
+		databasesQuery, tablesQuery = wraparoundDatabasesQuery94, wraparoundTablesQuery94
+		args = nil
+	}
+
+	res.Databases, err = s.fetchWraparoundRows(ctx, res, databasesQuery, args...)
+	if err != nil {
+		return res, err
+	}
+	res.Tables, err = s.fetchWraparoundRows(ctx, res, tablesQuery, append(args, tables)...)
+	if err != nil {
+		return res, err
+	}
+	res.XminHolders, err = s.fetchXminHolders(ctx)
+	return res, err
+}
+
+const wraparoundDatabasesQuery95 = `SELECT
+	oid,
+	'',
+	datname,
+	age(datfrozenxid),
+	mxid_age(datminmxid)
+	FROM pg_database
+	ORDER BY GREATEST(age(datfrozenxid)::float8 / NULLIF($1::bigint, 0), mxid_age(datminmxid)::float8 / NULLIF($2::bigint, 0)) DESC NULLS LAST,
+		age(datfrozenxid) DESC`
+
+const wraparoundDatabasesQuery94 = `SELECT
+	oid,
+	'',
+	datname,
+	age(datfrozenxid),
+	NULL
+	FROM pg_database
+	ORDER BY age(datfrozenxid) DESC`
+
+const wraparoundTablesQuery95 = `SELECT
+	c.oid,
+	n.nspname,
+	c.relname,
+	age(c.relfrozenxid),
+	mxid_age(c.relminmxid)
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'm', 't')
+	ORDER BY GREATEST(age(c.relfrozenxid)::float8 / NULLIF($1::bigint, 0), mxid_age(c.relminmxid)::float8 / NULLIF($2::bigint, 0)) DESC NULLS LAST,
+		age(c.relfrozenxid) DESC
+	LIMIT $3`
+
+// Multixact IDs are not tracked before PostgreSQL 9.5, so the order is by XID age only.
+const wraparoundTablesQuery94 = `SELECT
+	c.oid,
+	n.nspname,
+	c.relname,
+	age(c.relfrozenxid),
+	NULL
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'm', 't')
+	ORDER BY age(c.relfrozenxid) DESC
+	LIMIT $1`
+
+func (s *Stats) fetchWraparoundRows(ctx context.Context, view WraparoundView, query string, args ...interface{}) ([]WraparoundRow, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []WraparoundRow{}
+	for rows.Next() {
+		var row WraparoundRow
+
+		err := rows.Scan(
+			&row.Oid,
+			&row.Schemaname,
+			&row.Name,
+			&row.XidAge,
+			&row.MxidAge,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		oldest := row.XidAge
+		row.FreezeMaxAgePercent = percentOf(float64(row.XidAge), float64(view.FreezeMaxAge))
+		if row.MxidAge != nil && row.MxidAge.Valid {
+			row.MxidFreezeMaxAgePercent = percentOf(float64(row.MxidAge.Int64), float64(view.MultixactFreezeMaxAge))
+			if row.MxidAge.Int64 > oldest {
+				oldest = row.MxidAge.Int64
+			}
+		}
+		row.WraparoundPercent = percentOf(float64(oldest), wraparoundLimit)
+
+		data = append(data, row)
+	}
+	return data, rows.Err()
+}
+
+func (s *Stats) fetchXminHolders(ctx context.Context) ([]WraparoundXminHolder, error) {
+	const query = `SELECT 'backend', pid::text, datname, usename, backend_xmin::text::bigint, age(backend_xmin), xact_start
+	FROM pg_stat_activity WHERE backend_xmin IS NOT NULL
+	UNION ALL
+	SELECT 'replication slot', slot_name::text, database, NULL, xmin::text::bigint, age(xmin), NULL
+	FROM pg_replication_slots WHERE xmin IS NOT NULL
+	UNION ALL
+	SELECT 'replication slot catalog', slot_name::text, database, NULL, catalog_xmin::text::bigint, age(catalog_xmin), NULL
+	FROM pg_replication_slots WHERE catalog_xmin IS NOT NULL`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []WraparoundXminHolder{}
+	for rows.Next() {
+		var row WraparoundXminHolder
+
+		err := rows.Scan(
+			&row.Kind,
+			&row.Name,
+			&row.Datname,
+			&row.Usename,
+			&row.Xmin,
+			&row.XidAge,
+			&row.Since,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	prepared, err := s.fetchPreparedXacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, xact := range prepared {
+		data = append(data, WraparoundXminHolder{
+			Kind:    "prepared transaction",
+			Name:    xact.Gid,
+			Datname: xact.Database,
+			Usename: xact.Owner,
+			Xmin:    xact.Transaction,
+			XidAge:  xact.XidAge,
+			Since:   xact.Prepared,
+		})
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].XidAge > data[j].XidAge
+	})
+	return data, nil
+}
@@ -1,6 +1,9 @@
 package pgstats
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+)
 
 // XactAllTables represents content of `pg_stat_xact_all_tables` view.
 //
@@ -25,20 +28,31 @@ func (s *Stats) XactUserTables() ([]XactTablesRow, error) {
 
 // XactTablesRow represents schema of pg_stat_xact_*_tables views
 type XactTablesRow struct {
-	Relid       int64          `json:"relid"`         // OID of a table
-	Schemaname  string         `json:"schemaname"`    // Name of the schema that this table is in
-	Relname     string         `json:"relname"`       // Name of this table
-	SeqScan     *sql.NullInt64 `json:"seq_scan"`      // Number of sequential scans initiated on this table
-	SeqTupRead  *sql.NullInt64 `json:"seq_tup_read"`  // Number of live rows fetched by sequential scans
-	IdxScan     *sql.NullInt64 `json:"idx_scan"`      // Number of index scans initiated on this table
-	IdxTupFetch *sql.NullInt64 `json:"idx_tup_fetch"` // Number of live rows fetched by index scans
-	NTupIns     *sql.NullInt64 `json:"n_tup_ins"`     // Number of rows inserted
-	NTupUpd     *sql.NullInt64 `json:"n_tup_upd"`     // Number of rows updated (includes HOT updated rows)
-	NTupDel     *sql.NullInt64 `json:"n_tup_del"`     // Number of rows deleted
-	NTupHotUpd  *sql.NullInt64 `json:"n_tup_hot_upd"` // Number of rows HOT updated (i.e., with no separate index update required)
+	Relid          int64          `json:"relid"`             // OID of a table
+	Schemaname     string         `json:"schemaname"`        // Name of the schema that this table is in
+	Relname        string         `json:"relname"`           // Name of this table
+	SeqScan        *sql.NullInt64 `json:"seq_scan"`          // Number of sequential scans initiated on this table
+	SeqTupRead     *sql.NullInt64 `json:"seq_tup_read"`      // Number of live rows fetched by sequential scans
+	IdxScan        *sql.NullInt64 `json:"idx_scan"`          // Number of index scans initiated on this table
+	IdxTupFetch    *sql.NullInt64 `json:"idx_tup_fetch"`     // Number of live rows fetched by index scans
+	NTupIns        *sql.NullInt64 `json:"n_tup_ins"`         // Number of rows inserted
+	NTupUpd        *sql.NullInt64 `json:"n_tup_upd"`         // Number of rows updated (includes HOT updated rows)
+	NTupDel        *sql.NullInt64 `json:"n_tup_del"`         // Number of rows deleted
+	NTupHotUpd     *sql.NullInt64 `json:"n_tup_hot_upd"`     // Number of rows HOT updated (i.e., with no separate index update required)
+	NTupNewpageUpd *sql.NullInt64 `json:"n_tup_newpage_upd"` // Number of rows updated where the successor version goes onto a new heap page. Supported since PostgreSQL 16.
 }
 
 func (s *Stats) fetchXactTables(view string) ([]XactTablesRow, error) {
+	version, err := s.getVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	newpageUpd := "NULL"
+	if version >= 16 {
+		newpageUpd = "n_tup_newpage_upd"
+	}
+
 	const query = `SELECT
 	relid,
 	schemaname,
@@ -50,10 +64,11 @@ func (s *Stats) fetchXactTables(view string) ([]XactTablesRow, error) {
 	n_tup_ins,
 	n_tup_upd,
 	n_tup_del,
-	n_tup_hot_upd
-	FROM `
+	n_tup_hot_upd,
+	%s
+	FROM %s`
 
-	rows, err := s.db.Query(query + view)
+	rows, err := s.db.Query(fmt.Sprintf(query, newpageUpd, view))
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +90,7 @@ func (s *Stats) fetchXactTables(view string) ([]XactTablesRow, error) {
 			&row.NTupUpd,
 			&row.NTupDel,
 			&row.NTupHotUpd,
+			&row.NTupNewpageUpd,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,65 @@
+package pgstats
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SubscriptionLagRow represents lag of a subscription apply worker as seen from the subscriber.
+type SubscriptionLagRow struct {
+	Subid                 int64            `json:"subid"`                   // OID of the subscription
+	Subname               string           `json:"subname"`                 // Name of the subscription
+	Pid                   *sql.NullInt64   `json:"pid"`                     // Process ID of the apply worker, null if the worker is not running
+	FeedbackPendingBytes  *sql.NullInt64   `json:"feedback_pending_bytes"`  // Amount of WAL received but not yet confirmed to the origin WAL sender (received_lsn - latest_end_lsn), a feedback backlog rather than apply lag
+	LagSeconds            *sql.NullFloat64 `json:"lag_seconds"`             // Seconds since the last WAL location was reported to the origin WAL sender
+	TransportDelaySeconds *sql.NullFloat64 `json:"transport_delay_seconds"` // Seconds between send and receipt of the last message from the origin WAL sender
+}
+
+// SubscriptionLag returns lag of every subscription apply worker from Subscription rows at the given time.
+// Table synchronization and parallel apply workers are skipped. Values are null if the positions or times are unknown.
+// FeedbackPendingBytes shows how much received WAL the worker has not confirmed yet, the subscriber may have applied it already,
+// use pg_replication_slots or pg_stat_replication on the publisher to see how far the subscriber is behind.
+func SubscriptionLag(rows []SubscriptionRow, now time.Time) []SubscriptionLagRow {
+	data := []SubscriptionLagRow{}
+	for _, row := range rows {
+		if !isNull(row.Relid) || !isNull(row.LeaderPid) {
+			continue
+		}
+		if row.WorkerType != nil && row.WorkerType.Valid && row.WorkerType.String != "apply" {
+			continue
+		}
+
+		lag := SubscriptionLagRow{
+			Pid:                   row.Pid,
+			FeedbackPendingBytes:  &sql.NullInt64{},
+			LagSeconds:            &sql.NullFloat64{},
+			TransportDelaySeconds: &sql.NullFloat64{},
+		}
+		if !isNull(row.Subid) {
+			lag.Subid = row.Subid.Int64
+		}
+		if row.Subname != nil {
+			lag.Subname = row.Subname.String
+		}
+		if !isNull(row.ReceivedLsn) && !isNull(row.LatestEndLsn) {
+			pending := row.ReceivedLsn.Int64 - row.LatestEndLsn.Int64
+			if pending < 0 {
+				pending = 0
+			}
+			lag.FeedbackPendingBytes = &sql.NullInt64{Int64: pending, Valid: true}
+		}
+		if isValidTime(row.LatestEndTime) {
+			lag.LagSeconds = &sql.NullFloat64{Float64: now.Sub(row.LatestEndTime.Time).Seconds(), Valid: true}
+		}
+		if isValidTime(row.LastMsgSendTime) && isValidTime(row.LastMsgReceiptTime) {
+			delay := row.LastMsgReceiptTime.Time.Sub(row.LastMsgSendTime.Time).Seconds()
+			lag.TransportDelaySeconds = &sql.NullFloat64{Float64: delay, Valid: true}
+		}
+		data = append(data, lag)
+	}
+	return data
+}
+
+func isValidTime(v *sql.NullTime) bool {
+	return v != nil && v.Valid
+}
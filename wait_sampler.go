@@ -0,0 +1,249 @@
+package pgstats
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WaitSampler polls `pg_stat_activity` in a background goroutine and aggregates wait events
+// of active sessions into a histogram, like an active session history (ASH) profile.
+// Active sessions which are not waiting are counted with "CPU" wait event type.
+type WaitSampler struct {
+	stats    *Stats
+	interval time.Duration
+	poller   poller
+	query    string
+
+	mu         sync.Mutex
+	events     map[waitKey]int64
+	texts      map[int64]string
+	keyedTexts int
+	samples    int64
+	start      time.Time
+	end        time.Time
+}
+
+// WaitProfile represents wait events aggregated by WaitSampler over a time window.
+type WaitProfile struct {
+	Start   time.Time      `json:"start"`   // Time of the first sample
+	End     time.Time      `json:"end"`     // Time of the last sample
+	Samples int64          `json:"samples"` // Number of polls of pg_stat_activity
+	Events  []WaitEventRow `json:"events"`  // Wait events, the most frequent first
+}
+
+// WaitEventRow represents how many times active sessions were seen in the same wait event.
+type WaitEventRow struct {
+	BackendType    string         `json:"backend_type"`    // Type of the backends, empty before PostgreSQL 10
+	WaitEventType  string         `json:"wait_event_type"` // The type of event for which the backends were waiting, CPU if they were not waiting
+	WaitEvent      string         `json:"wait_event"`      // Wait event name, empty if the backends were not waiting
+	QueryID        *sql.NullInt64 `json:"query_id"`        // Identifier of the backends' query. Supported since PostgreSQL 14.
+	Query          string         `json:"query"`           // Text of the backends' query truncated to 1024 characters, empty for other queries over the limit of distinct texts
+	Count          int64          `json:"count"`           // Number of times a session was seen in this wait event
+	ActiveSessions float64        `json:"active_sessions"` // Average number of sessions in this wait event per sample
+}
+
+type waitKey struct {
+	backendType   string
+	waitEventType string
+	waitEvent     string
+	queryID       int64
+	hasQueryID    bool
+	query         string // set only if query_id is not available
+}
+
+// waitMaxQueryTexts bounds the number of distinct query texts used as keys when query_id is not available,
+// texts of other queries are aggregated under an empty query.
+const waitMaxQueryTexts = 1000
+
+// NewWaitSampler creates a new WaitSampler polling pg_stat_activity every interval.
+// A non-positive interval is replaced with one second.
+func (s *Stats) NewWaitSampler(interval time.Duration) *WaitSampler {
+	return &WaitSampler{
+		stats:    s,
+		interval: pollInterval(interval),
+		events:   map[waitKey]int64{},
+		texts:    map[int64]string{},
+	}
+}
+
+// Start starts polling in a background goroutine until ctx is done or Stop is called.
+// Calling Start on a running sampler does nothing.
+func (ws *WaitSampler) Start(ctx context.Context) {
+	ws.poller.start(ctx, ws.interval, ws.sample)
+}
+
+// Stop stops polling and waits for the background goroutine to exit.
+// It returns the last error of polling, if any.
+func (ws *WaitSampler) Stop() error {
+	return ws.poller.stop()
+}
+
+// Err returns the last error of polling, if any.
+func (ws *WaitSampler) Err() error {
+	return ws.poller.lastErr()
+}
+
+// Reset discards all samples collected so far.
+func (ws *WaitSampler) Reset() {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.events = map[waitKey]int64{}
+	ws.texts = map[int64]string{}
+	ws.keyedTexts = 0
+	ws.samples = 0
+	ws.start, ws.end = time.Time{}, time.Time{}
+}
+
+// Profile returns wait events collected since the sampler was created or reset.
+func (ws *WaitSampler) Profile() WaitProfile {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	res := WaitProfile{
+		Start:   ws.start,
+		End:     ws.end,
+		Samples: ws.samples,
+		Events:  make([]WaitEventRow, 0, len(ws.events)),
+	}
+	for key, count := range ws.events {
+		row := WaitEventRow{
+			BackendType:   key.backendType,
+			WaitEventType: key.waitEventType,
+			WaitEvent:     key.waitEvent,
+			QueryID:       &sql.NullInt64{Int64: key.queryID, Valid: key.hasQueryID},
+			Count:         count,
+		}
+		if key.hasQueryID {
+			row.Query = ws.texts[key.queryID]
+		} else {
+			row.Query = key.query
+		}
+		if ws.samples > 0 {
+			row.ActiveSessions = float64(count) / float64(ws.samples)
+		}
+		res.Events = append(res.Events, row)
+	}
+
+	sort.Slice(res.Events, func(i, j int) bool {
+		return res.Events[i].Count > res.Events[j].Count
+	})
+	return res
+}
+
+func (ws *WaitSampler) getQuery(ctx context.Context) (string, error) {
+	version, err := ws.stats.getVersionContext(ctx)
+	switch {
+	case err != nil:
+		return "", err
+	case version >= 14:
+		return waitSamplerQuery14, nil
+	case version >= 10:
+		return waitSamplerQuery10, nil
+	case version == 9.6:
+		return waitSamplerQuery96, nil
+	default:
+		return waitSamplerQuery95, nil
+	}
+}
+
+const waitSamplerQuery14 = `SELECT backend_type, wait_event_type, wait_event, query_id, left(query, 1024)
+	FROM pg_stat_activity
+	WHERE state = 'active' AND pid <> pg_backend_pid()`
+
+const waitSamplerQuery10 = `SELECT backend_type, wait_event_type, wait_event, NULL, left(query, 1024)
+	FROM pg_stat_activity
+	WHERE state = 'active' AND pid <> pg_backend_pid()`
+
+const waitSamplerQuery96 = `SELECT NULL, wait_event_type, wait_event, NULL, left(query, 1024)
+	FROM pg_stat_activity
+	WHERE state = 'active' AND pid <> pg_backend_pid()`
+
+const waitSamplerQuery95 = `SELECT NULL, CASE WHEN waiting THEN 'Lock' END, NULL, NULL, left(query, 1024)
+	FROM pg_stat_activity
+	WHERE state = 'active' AND pid <> pg_backend_pid()`
+
+// sample is called by the poller goroutine only.
+func (ws *WaitSampler) sample(ctx context.Context) error {
+	if ws.query == "" {
+		query, err := ws.getQuery(ctx)
+		if err != nil {
+			return err
+		}
+		ws.query = query
+	}
+
+	rows, err := ws.stats.db.QueryContext(ctx, ws.query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	keys := []waitKey{}
+	texts := map[int64]string{}
+	for rows.Next() {
+		var backendType, waitEventType, waitEvent, text sql.NullString
+		var queryID sql.NullInt64
+
+		err := rows.Scan(
+			&backendType,
+			&waitEventType,
+			&waitEvent,
+			&queryID,
+			&text,
+		)
+		if err != nil {
+			return err
+		}
+
+		key := waitKey{
+			backendType:   backendType.String,
+			waitEventType: waitEventType.String,
+			waitEvent:     waitEvent.String,
+			queryID:       queryID.Int64,
+			hasQueryID:    queryID.Valid,
+		}
+		if !waitEventType.Valid {
+			key.waitEventType = "CPU"
+		}
+		if queryID.Valid {
+			texts[queryID.Int64] = text.String
+		} else {
+			key.query = text.String
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if ws.samples == 0 {
+		ws.start = now
+	}
+	ws.end = now
+	ws.samples++
+	for _, key := range keys {
+		if _, ok := ws.events[key]; !ok && key.query != "" {
+			if ws.keyedTexts >= waitMaxQueryTexts {
+				key.query = ""
+			} else {
+				ws.keyedTexts++
+			}
+		}
+		ws.events[key]++
+	}
+	for queryID, text := range texts {
+		if _, ok := ws.texts[queryID]; !ok {
+			ws.texts[queryID] = text
+		}
+	}
+	return nil
+}